@@ -0,0 +1,16 @@
+// Package blacklist tracks access token jtis that have been explicitly
+// revoked (e.g. via logout) so they can be rejected before their natural
+// expiry, even though the JWT signature itself is still valid.
+package blacklist
+
+import "time"
+
+// TokenBlacklist records revoked access tokens by their jti claim.
+type TokenBlacklist interface {
+	// Add marks jti as revoked until ttl elapses. ttl should be set to the
+	// remaining lifetime of the token so the entry disappears naturally
+	// once the token would have expired anyway.
+	Add(jti string, ttl time.Duration) error
+	// IsBlacklisted reports whether jti has been revoked.
+	IsBlacklisted(jti string) (bool, error)
+}
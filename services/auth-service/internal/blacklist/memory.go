@@ -0,0 +1,48 @@
+package blacklist
+
+import (
+	"sync"
+	"time"
+)
+
+// InMemoryBlacklist is a process-local TokenBlacklist with no external
+// dependencies. It's used for local development and tests when REDIS_URL
+// isn't configured; it does not share state across replicas.
+type InMemoryBlacklist struct {
+	mu      sync.Mutex
+	entries map[string]time.Time // jti -> expiry
+}
+
+func NewInMemoryBlacklist() *InMemoryBlacklist {
+	return &InMemoryBlacklist{
+		entries: make(map[string]time.Time),
+	}
+}
+
+func (b *InMemoryBlacklist) Add(jti string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.entries[jti] = time.Now().Add(ttl)
+	return nil
+}
+
+func (b *InMemoryBlacklist) IsBlacklisted(jti string) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	expiresAt, ok := b.entries[jti]
+	if !ok {
+		return false, nil
+	}
+
+	if time.Now().After(expiresAt) {
+		delete(b.entries, jti)
+		return false, nil
+	}
+
+	return true, nil
+}
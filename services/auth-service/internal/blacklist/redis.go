@@ -0,0 +1,66 @@
+package blacklist
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const keyPrefix = "auth:blacklist:"
+
+// RedisBlacklist backs TokenBlacklist with Redis so the denylist is shared
+// across every replica of the service.
+type RedisBlacklist struct {
+	client *redis.Client
+}
+
+// NewRedisBlacklist connects to Redis using redisURL (a redis:// or
+// rediss:// connection string) and the given connection pool size.
+func NewRedisBlacklist(redisURL string, poolSize int, useTLS bool) (*RedisBlacklist, error) {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse redis url: %w", err)
+	}
+
+	opts.PoolSize = poolSize
+	if useTLS && opts.TLSConfig == nil {
+		opts.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	client := redis.NewClient(opts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+
+	return &RedisBlacklist{client: client}, nil
+}
+
+func (b *RedisBlacklist) Add(jti string, ttl time.Duration) error {
+	if ttl <= 0 {
+		// Token has already expired naturally; nothing to blacklist.
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return b.client.Set(ctx, keyPrefix+jti, "1", ttl).Err()
+}
+
+func (b *RedisBlacklist) IsBlacklisted(jti string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	n, err := b.client.Exists(ctx, keyPrefix+jti).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check token blacklist: %w", err)
+	}
+
+	return n > 0, nil
+}
@@ -1,18 +1,68 @@
 package config
 
 import (
+	"encoding/json"
 	"net/url"
 	"os"
 	"strconv"
 )
 
+// OIDCProviderConfig describes a single configured social/OIDC login
+// provider, keyed by Name (e.g. "google", "github").
+type OIDCProviderConfig struct {
+	Name         string   `json:"name"`
+	ClientID     string   `json:"clientId"`
+	ClientSecret string   `json:"clientSecret"`
+	Issuer       string   `json:"issuer"`
+	Scopes       []string `json:"scopes"`
+	RedirectURL  string   `json:"redirectUrl"`
+}
+
 type Config struct {
 	Port        int
 	Environment string
 	LogLevel    string
 	DatabaseURL string
-	JWTSecret   string
 	TokenExpiry int // in hours
+
+	JWTAlg                 string
+	JWTPrivateKeyPath      string
+	JWTCurrentKID          string
+	JWTIssuer              string
+	JWTKeyRotationInterval int // in hours; 0 disables automatic rotation
+
+	RedisURL      string
+	RedisPoolSize int
+	RedisTLS      bool
+
+	AppBaseURL       string
+	PasswordResetTTL int // in minutes
+	SMTPHost         string
+	SMTPPort         int
+	SMTPUser         string
+	SMTPPass         string
+	SMTPFrom         string
+
+	OIDCProviders           []OIDCProviderConfig
+	OAuthTokenEncryptionKey string
+
+	MFAEncryptionKey string
+
+	RolePermissions map[string][]string
+
+	WebAuthnRPID          string
+	WebAuthnRPOrigins     []string
+	WebAuthnRPDisplayName string
+
+	LoginRateLimitAttempts          int
+	LoginRateLimitWindow            int // in minutes
+	RegisterRateLimitAttempts       int
+	RegisterRateLimitWindow         int // in minutes
+	ForgotPasswordRateLimitAttempts int
+	ForgotPasswordRateLimitWindow   int // in minutes
+
+	AccountLockoutThreshold  int // consecutive failures before locking
+	AccountLockoutBaseWindow int // in minutes; doubles with each repeat lockout
 }
 
 func Load() *Config {
@@ -21,13 +71,105 @@ func Load() *Config {
 		Environment: getEnv("NODE_ENV", "production"),
 		LogLevel:    getEnv("LOG_LEVEL", "info"),
 		DatabaseURL: buildDatabaseURL(),
-		JWTSecret:   getEnv("JWT_SECRET", "your-jwt-secret-key"),
 		TokenExpiry: getEnvAsInt("TOKEN_EXPIRY", 24),
+
+		JWTAlg:                 getEnv("JWT_ALG", "RS256"),
+		JWTPrivateKeyPath:      getEnv("JWT_PRIVATE_KEY_PATH", "./keys"),
+		JWTCurrentKID:          getEnv("JWT_CURRENT_KID", ""),
+		JWTIssuer:              getEnv("JWT_ISSUER", "http://localhost:8080"),
+		JWTKeyRotationInterval: getEnvAsInt("JWT_KEY_ROTATION_INTERVAL_HOURS", 0),
+
+		RedisURL:      getEnv("REDIS_URL", ""),
+		RedisPoolSize: getEnvAsInt("REDIS_POOL_SIZE", 10),
+		RedisTLS:      getEnvAsBool("REDIS_TLS", false),
+
+		AppBaseURL:       getEnv("APP_BASE_URL", "http://localhost:3000"),
+		PasswordResetTTL: getEnvAsInt("PASSWORD_RESET_TTL_MINUTES", 30),
+		SMTPHost:         getEnv("SMTP_HOST", ""),
+		SMTPPort:         getEnvAsInt("SMTP_PORT", 587),
+		SMTPUser:         getEnv("SMTP_USER", ""),
+		SMTPPass:         getEnv("SMTP_PASS", ""),
+		SMTPFrom:         getEnv("SMTP_FROM", "no-reply@example.com"),
+
+		OIDCProviders:           loadOIDCProviders(),
+		OAuthTokenEncryptionKey: getEnv("OAUTH_TOKEN_ENCRYPTION_KEY", ""),
+
+		MFAEncryptionKey: getEnv("MFA_ENCRYPTION_KEY", ""),
+
+		RolePermissions: loadRolePermissions(),
+
+		WebAuthnRPID:          getEnv("WEBAUTHN_RP_ID", "localhost"),
+		WebAuthnRPOrigins:     loadWebAuthnRPOrigins(),
+		WebAuthnRPDisplayName: getEnv("WEBAUTHN_RP_DISPLAY_NAME", "Auth Service"),
+
+		LoginRateLimitAttempts:          getEnvAsInt("LOGIN_RATE_LIMIT_ATTEMPTS", 5),
+		LoginRateLimitWindow:            getEnvAsInt("LOGIN_RATE_LIMIT_WINDOW_MINUTES", 15),
+		RegisterRateLimitAttempts:       getEnvAsInt("REGISTER_RATE_LIMIT_ATTEMPTS", 10),
+		RegisterRateLimitWindow:         getEnvAsInt("REGISTER_RATE_LIMIT_WINDOW_MINUTES", 60),
+		ForgotPasswordRateLimitAttempts: getEnvAsInt("FORGOT_PASSWORD_RATE_LIMIT_ATTEMPTS", 3),
+		ForgotPasswordRateLimitWindow:   getEnvAsInt("FORGOT_PASSWORD_RATE_LIMIT_WINDOW_MINUTES", 60),
+
+		AccountLockoutThreshold:  getEnvAsInt("ACCOUNT_LOCKOUT_THRESHOLD", 5),
+		AccountLockoutBaseWindow: getEnvAsInt("ACCOUNT_LOCKOUT_BASE_WINDOW_MINUTES", 1),
 	}
 
 	return config
 }
 
+// loadWebAuthnRPOrigins parses the WEBAUTHN_RP_ORIGINS environment variable,
+// a JSON array of allowed origins for WebAuthn ceremonies, falling back to
+// AppBaseURL so a single-origin deployment works without extra config.
+func loadWebAuthnRPOrigins() []string {
+	raw := os.Getenv("WEBAUTHN_RP_ORIGINS")
+	if raw == "" {
+		return []string{getEnv("APP_BASE_URL", "http://localhost:3000")}
+	}
+
+	var origins []string
+	if err := json.Unmarshal([]byte(raw), &origins); err != nil {
+		return []string{getEnv("APP_BASE_URL", "http://localhost:3000")}
+	}
+
+	return origins
+}
+
+// loadRolePermissions parses the ROLE_PERMISSIONS environment variable, a
+// JSON object mapping role name to a list of permission names, so grants
+// can be tuned per deployment without a code change. An empty or invalid
+// value leaves it nil, and rbac.NewRegistry(nil) falls back to no grants --
+// callers that want the built-in defaults should use rbac.DefaultRegistry
+// instead when this is empty.
+func loadRolePermissions() map[string][]string {
+	raw := os.Getenv("ROLE_PERMISSIONS")
+	if raw == "" {
+		return nil
+	}
+
+	var rolePermissions map[string][]string
+	if err := json.Unmarshal([]byte(raw), &rolePermissions); err != nil {
+		return nil
+	}
+
+	return rolePermissions
+}
+
+// loadOIDCProviders parses the OIDC_PROVIDERS environment variable, a JSON
+// array of OIDCProviderConfig, so new social login providers can be added
+// without a code change.
+func loadOIDCProviders() []OIDCProviderConfig {
+	raw := os.Getenv("OIDC_PROVIDERS")
+	if raw == "" {
+		return nil
+	}
+
+	var providers []OIDCProviderConfig
+	if err := json.Unmarshal([]byte(raw), &providers); err != nil {
+		return nil
+	}
+
+	return providers
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -44,6 +186,15 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
 func buildDatabaseURL() string {
 	// Check for DATABASE_URL first (common in cloud deployments)
 	if dbURL := os.Getenv("DATABASE_URL"); dbURL != "" {
@@ -59,4 +210,4 @@ func buildDatabaseURL() string {
 	sslmode := getEnv("DB_SSLMODE", "disable")
 
 	return "postgres://" + url.QueryEscape(user) + ":" + url.QueryEscape(password) + "@" + host + ":" + port + "/" + dbname + "?sslmode=" + sslmode
-}
\ No newline at end of file
+}
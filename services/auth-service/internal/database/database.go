@@ -3,10 +3,10 @@ package database
 import (
 	"database/sql"
 	"fmt"
+	"log/slog"
 	"time"
 
 	_ "github.com/lib/pq"
-	"github.com/sirupsen/logrus"
 )
 
 func Initialize(databaseURL string) (*sql.DB, error) {
@@ -25,7 +25,7 @@ func Initialize(databaseURL string) (*sql.DB, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	logrus.Info("Successfully connected to database")
+	slog.Info("Successfully connected to database")
 	return db, nil
 }
 
@@ -40,34 +40,98 @@ func Migrate(db *sql.DB) error {
 			first_name VARCHAR(100),
 			last_name VARCHAR(100),
 			role VARCHAR(50) DEFAULT 'user',
+			login_type VARCHAR(20) NOT NULL DEFAULT 'password',
 			is_active BOOLEAN DEFAULT true,
 			created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
 			updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
 			last_login_at TIMESTAMP WITH TIME ZONE
 		);`,
+		`ALTER TABLE users ADD COLUMN IF NOT EXISTS login_type VARCHAR(20) NOT NULL DEFAULT 'password';`,
+		`ALTER TABLE users ALTER COLUMN password_hash DROP NOT NULL;`,
 		
 		`CREATE TABLE IF NOT EXISTS refresh_tokens (
 			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
 			user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
-			token VARCHAR(512) UNIQUE NOT NULL,
+			token_hash VARCHAR(512) UNIQUE NOT NULL,
 			expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
 			created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+			revoked_at TIMESTAMP WITH TIME ZONE,
 			is_revoked BOOLEAN DEFAULT false
 		);`,
 		
 		`CREATE TABLE IF NOT EXISTS password_reset_tokens (
 			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
 			user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
-			token VARCHAR(512) UNIQUE NOT NULL,
+			token_hash VARCHAR(512) UNIQUE NOT NULL,
 			expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
 			created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
 			used_at TIMESTAMP WITH TIME ZONE
 		);`,
-		
+
+		`CREATE TABLE IF NOT EXISTS user_links (
+			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+			user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			provider VARCHAR(50) NOT NULL,
+			provider_user_id VARCHAR(255) NOT NULL,
+			access_token_enc TEXT,
+			refresh_token_enc TEXT,
+			expires_at TIMESTAMP WITH TIME ZONE,
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+			updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+			UNIQUE(provider, provider_user_id)
+		);`,
+
+		`CREATE TABLE IF NOT EXISTS user_mfa (
+			user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+			secret_enc TEXT NOT NULL,
+			confirmed_at TIMESTAMP WITH TIME ZONE,
+			recovery_codes_hashed TEXT[] NOT NULL DEFAULT '{}',
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+			updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+		);`,
+
+		`CREATE TABLE IF NOT EXISTS sessions (
+			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+			user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			user_agent VARCHAR(512) NOT NULL DEFAULT '',
+			client_ip VARCHAR(64) NOT NULL DEFAULT '',
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+			last_used_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+			revoked_at TIMESTAMP WITH TIME ZONE
+		);`,
+
+		`CREATE TABLE IF NOT EXISTS webauthn_credentials (
+			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+			user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			credential_id BYTEA UNIQUE NOT NULL,
+			public_key BYTEA NOT NULL,
+			attestation_type VARCHAR(50) NOT NULL DEFAULT '',
+			aaguid BYTEA,
+			sign_count BIGINT NOT NULL DEFAULT 0,
+			transports TEXT[] NOT NULL DEFAULT '{}',
+			user_verified BOOLEAN NOT NULL DEFAULT false,
+			backup_eligible BOOLEAN NOT NULL DEFAULT false,
+			backup_state BOOLEAN NOT NULL DEFAULT false,
+			compromised BOOLEAN NOT NULL DEFAULT false,
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+			updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+			last_used_at TIMESTAMP WITH TIME ZONE
+		);`,
+
+		`CREATE TABLE IF NOT EXISTS account_lockouts (
+			identifier VARCHAR(255) PRIMARY KEY,
+			failure_count INTEGER NOT NULL DEFAULT 0,
+			locked_until TIMESTAMP WITH TIME ZONE,
+			updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+		);`,
+
 		`CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);`,
-		`CREATE INDEX IF NOT EXISTS idx_refresh_tokens_token ON refresh_tokens(token);`,
+		`CREATE INDEX IF NOT EXISTS idx_webauthn_credentials_user_id ON webauthn_credentials(user_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_user_links_user_id ON user_links(user_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_refresh_tokens_token_hash ON refresh_tokens(token_hash);`,
 		`CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens(user_id);`,
-		`CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_token ON password_reset_tokens(token);`,
+		`CREATE INDEX IF NOT EXISTS idx_sessions_user_id ON sessions(user_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_token_hash ON password_reset_tokens(token_hash);`,
 	}
 
 	for _, migration := range migrations {
@@ -76,6 +140,6 @@ func Migrate(db *sql.DB) error {
 		}
 	}
 
-	logrus.Info("Database migrations completed successfully")
+	slog.Info("Database migrations completed successfully")
 	return nil
 }
\ No newline at end of file
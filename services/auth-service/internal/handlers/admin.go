@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"auth-service/internal/logging"
+	"auth-service/internal/models"
+	"auth-service/internal/rbac"
+)
+
+// ListUsers returns a paginated list of accounts, optionally filtered by
+// role, active status, or email substring.
+func (h *AuthHandler) ListUsers(c *gin.Context) {
+	page, _ := strconv.Atoi(c.Query("page"))
+	pageSize, _ := strconv.Atoi(c.Query("pageSize"))
+
+	filter := models.UserListFilter{
+		Role:   c.Query("role"),
+		Search: c.Query("search"),
+	}
+	if active := c.Query("isActive"); active != "" {
+		if parsed, err := strconv.ParseBool(active); err == nil {
+			filter.IsActive = &parsed
+		}
+	}
+
+	result, err := h.authService.ListUsers(filter, page, pageSize)
+	if err != nil {
+		logging.FromContext(c.Request.Context()).Error("Failed to list users", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to list users",
+			"message": "Please try again",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UpdateUserRole changes the target user's role. The caller must outrank
+// the role being granted (see AuthService.UpdateUserRole), so a plain admin
+// can't use this endpoint to promote anyone — including themselves — to
+// admin or superadmin.
+func (h *AuthHandler) UpdateUserRole(c *gin.Context) {
+	caller, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid user ID",
+			"message": "The provided user ID is not valid",
+		})
+		return
+	}
+
+	var req models.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Validation failed",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	if err := h.authService.UpdateUserRole(rbac.Role(caller.Role), userID, req.Role); err != nil {
+		if err.Error() == "insufficient privilege to grant this role" {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":   "Insufficient privilege",
+				"message": "You don't have permission to grant this role",
+			})
+			return
+		}
+		logging.FromContext(c.Request.Context()).Error("Failed to update user role", "error", err, "user_id", userID)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to update role",
+			"message": "Please try again",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Role updated successfully",
+	})
+}
+
+// ActivateUser re-enables a previously deactivated account.
+func (h *AuthHandler) ActivateUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid user ID",
+			"message": "The provided user ID is not valid",
+		})
+		return
+	}
+
+	if err := h.authService.ActivateUser(userID); err != nil {
+		logging.FromContext(c.Request.Context()).Error("Failed to activate user", "error", err, "user_id", userID)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to activate user",
+			"message": "Please try again",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User activated successfully",
+	})
+}
+
+// DeactivateUser disables an account and signs it out everywhere.
+func (h *AuthHandler) DeactivateUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid user ID",
+			"message": "The provided user ID is not valid",
+		})
+		return
+	}
+
+	if err := h.authService.DeactivateUser(userID); err != nil {
+		logging.FromContext(c.Request.Context()).Error("Failed to deactivate user", "error", err, "user_id", userID)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to deactivate user",
+			"message": "Please try again",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User deactivated successfully",
+	})
+}
+
+// ForceLogout revokes every refresh token issued to a user.
+func (h *AuthHandler) ForceLogout(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid user ID",
+			"message": "The provided user ID is not valid",
+		})
+		return
+	}
+
+	if err := h.authService.ForceLogout(userID); err != nil {
+		logging.FromContext(c.Request.Context()).Error("Failed to force logout", "error", err, "user_id", userID)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to log out user",
+			"message": "Please try again",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User logged out everywhere successfully",
+	})
+}
@@ -1,25 +1,29 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
-	"github.com/sirupsen/logrus"
+	"github.com/prometheus/client_golang/prometheus"
 
+	"auth-service/internal/logging"
 	"auth-service/internal/models"
 	"auth-service/internal/service"
 )
 
 type AuthHandler struct {
-	authService *service.AuthService
-	validator   *validator.Validate
+	authService  *service.AuthService
+	validator    *validator.Validate
+	authAttempts *prometheus.CounterVec
 }
 
-func NewAuthHandler(authService *service.AuthService) *AuthHandler {
+func NewAuthHandler(authService *service.AuthService, authAttempts *prometheus.CounterVec) *AuthHandler {
 	return &AuthHandler{
-		authService: authService,
-		validator:   validator.New(),
+		authService:  authService,
+		validator:    validator.New(),
+		authAttempts: authAttempts,
 	}
 }
 
@@ -44,7 +48,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 	user, err := h.authService.Register(&req)
 	if err != nil {
-		logrus.WithError(err).Error("Registration failed")
+		logging.FromContext(c.Request.Context()).Error("Registration failed", "error", err)
 		
 		if err.Error() == "user already exists" {
 			c.JSON(http.StatusConflict, gin.H{
@@ -62,9 +66,9 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	}
 
 	// Generate tokens
-	authResponse, err := h.authService.GenerateTokens(user)
+	authResponse, err := h.authService.GenerateTokens(user, c.GetHeader("User-Agent"), c.ClientIP())
 	if err != nil {
-		logrus.WithError(err).Error("Token generation failed")
+		logging.FromContext(c.Request.Context()).Error("Token generation failed", "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Registration successful but token generation failed",
 			"message": "Please try logging in",
@@ -72,7 +76,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	logrus.WithField("user_id", user.ID).Info("User registered successfully")
+	logging.FromContext(c.Request.Context()).Info("User registered successfully", "user_id", user.ID)
 	
 	c.JSON(http.StatusCreated, gin.H{
 		"message": "Registration successful",
@@ -104,9 +108,28 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	}
 
 	user, err := h.authService.Login(&req)
+	if errors.Is(err, service.ErrWebAuthnRequired) {
+		resp, beginErr := h.authService.BeginLogin(&models.WebAuthnLoginBeginRequest{Email: req.Email})
+		if beginErr != nil {
+			logging.FromContext(c.Request.Context()).Error("Failed to begin webauthn login", "error", beginErr)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Login failed",
+				"message": "Please try again",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "WebAuthn verification required",
+			"webauthnRequired": true,
+			"challengeId": resp.ChallengeID,
+			"options": resp.Options,
+		})
+		return
+	}
 	if err != nil {
-		logrus.WithError(err).WithField("email", req.Email).Warning("Login attempt failed")
-		
+		logging.FromContext(c.Request.Context()).Warn("Login attempt failed", "error", err, "email", req.Email)
+
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"error": "Invalid credentials",
 			"message": "Email or password is incorrect",
@@ -114,10 +137,39 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
+	needsMFA, err := h.authService.NeedsMFA(user.ID)
+	if err != nil {
+		logging.FromContext(c.Request.Context()).Error("Failed to check mfa status", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Login failed",
+			"message": "Please try again",
+		})
+		return
+	}
+
+	if needsMFA {
+		mfaToken, err := h.authService.GenerateMFAPendingToken(user)
+		if err != nil {
+			logging.FromContext(c.Request.Context()).Error("Failed to generate mfa pending token", "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Login failed",
+				"message": "Please try again",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "MFA verification required",
+			"mfaRequired": true,
+			"mfaToken": mfaToken,
+		})
+		return
+	}
+
 	// Generate tokens
-	authResponse, err := h.authService.GenerateTokens(user)
+	authResponse, err := h.authService.GenerateTokens(user, c.GetHeader("User-Agent"), c.ClientIP())
 	if err != nil {
-		logrus.WithError(err).Error("Token generation failed")
+		logging.FromContext(c.Request.Context()).Error("Token generation failed", "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Login successful but token generation failed",
 			"message": "Please try again",
@@ -127,10 +179,10 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	// Update last login time
 	if err := h.authService.UpdateLastLogin(user.ID); err != nil {
-		logrus.WithError(err).Warning("Failed to update last login time")
+		logging.FromContext(c.Request.Context()).Warn("Failed to update last login time", "error", err)
 	}
 
-	logrus.WithField("user_id", user.ID).Info("User logged in successfully")
+	logging.FromContext(c.Request.Context()).Info("User logged in successfully", "user_id", user.ID)
 	
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Login successful",
@@ -163,7 +215,7 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 
 	authResponse, err := h.authService.RefreshToken(req.RefreshToken)
 	if err != nil {
-		logrus.WithError(err).Warning("Token refresh failed")
+		logging.FromContext(c.Request.Context()).Warn("Token refresh failed", "error", err)
 		
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"error": "Invalid refresh token",
@@ -232,9 +284,16 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 		token = authHeader[7:]
 	}
 
-	err := h.authService.Logout(token)
+	// The refresh token is optional in the body so older clients that only
+	// send the access token keep working; the session named by the access
+	// token is revoked either way, and a refresh token sent here is also
+	// revoked directly.
+	var req models.LogoutRequest
+	_ = c.ShouldBindJSON(&req)
+
+	err := h.authService.Logout(token, req.RefreshToken)
 	if err != nil {
-		logrus.WithError(err).Warning("Logout failed")
+		logging.FromContext(c.Request.Context()).Warn("Logout failed", "error", err)
 		// Still return success for security reasons
 	}
 
@@ -262,9 +321,9 @@ func (h *AuthHandler) ForgotPassword(c *gin.Context) {
 		return
 	}
 
-	err := h.authService.ForgotPassword(req.Email)
+	err := h.authService.ForgotPassword(req.Email, c.ClientIP())
 	if err != nil {
-		logrus.WithError(err).Warning("Password reset request failed")
+		logging.FromContext(c.Request.Context()).Warn("Password reset request failed", "error", err)
 	}
 
 	// Always return success for security reasons
@@ -294,7 +353,7 @@ func (h *AuthHandler) ResetPassword(c *gin.Context) {
 
 	err := h.authService.ResetPassword(req.Token, req.Password)
 	if err != nil {
-		logrus.WithError(err).Warning("Password reset failed")
+		logging.FromContext(c.Request.Context()).Warn("Password reset failed", "error", err)
 		
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": "Password reset failed",
@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"auth-service/internal/logging"
+	"auth-service/internal/middleware"
+	"auth-service/internal/models"
+)
+
+// currentUser fetches the caller's identity stored by middleware.Authenticate,
+// which must run ahead of any handler that calls this.
+func (h *AuthHandler) currentUser(c *gin.Context) (*models.User, bool) {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "Authentication required",
+			"message": "Please login",
+		})
+		return nil, false
+	}
+
+	return user, true
+}
+
+// MFAEnroll starts a TOTP enrollment for the authenticated user, returning
+// the QR code and recovery codes. The enrollment isn't active until
+// MFAConfirm validates the first code.
+func (h *AuthHandler) MFAEnroll(c *gin.Context) {
+	user, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	enrollment, err := h.authService.EnrollMFA(user)
+	if err != nil {
+		logging.FromContext(c.Request.Context()).Error("Failed to enroll mfa", "error", err, "user_id", user.ID)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "MFA enrollment failed",
+			"message": "Please try again",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, enrollment)
+}
+
+// MFAConfirm activates a pending TOTP enrollment after the user proves they
+// can generate a valid code.
+func (h *AuthHandler) MFAConfirm(c *gin.Context) {
+	user, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req models.MFAConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Validation failed",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	if err := h.authService.ConfirmMFA(user.ID, req.Code); err != nil {
+		h.authAttempts.WithLabelValues("failure", "totp").Inc()
+		logging.FromContext(c.Request.Context()).Warn("MFA confirmation failed", "error", err, "user_id", user.ID)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "MFA confirmation failed",
+			"message": "Invalid or expired code",
+		})
+		return
+	}
+	h.authAttempts.WithLabelValues("success", "totp").Inc()
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "MFA enabled successfully",
+	})
+}
+
+// MFADisable removes the authenticated user's TOTP enrollment after
+// checking a current code, proving they still hold the second factor.
+func (h *AuthHandler) MFADisable(c *gin.Context) {
+	user, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req models.MFAConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Validation failed",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	if err := h.authService.DisableMFA(user.ID, req.Code); err != nil {
+		h.authAttempts.WithLabelValues("failure", "totp").Inc()
+		logging.FromContext(c.Request.Context()).Warn("MFA disable failed", "error", err, "user_id", user.ID)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "MFA disable failed",
+			"message": "Invalid or expired code",
+		})
+		return
+	}
+	h.authAttempts.WithLabelValues("success", "totp").Inc()
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "MFA disabled successfully",
+	})
+}
+
+// MFAVerify completes a login for a user whose account requires MFA, using
+// the mfaToken issued by Login and a current TOTP code.
+func (h *AuthHandler) MFAVerify(c *gin.Context) {
+	var req models.MFAVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Validation failed",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	user, err := h.authService.VerifyMFALogin(req.MFAToken, req.Code)
+	if err != nil {
+		h.authAttempts.WithLabelValues("failure", "totp").Inc()
+		logging.FromContext(c.Request.Context()).Warn("MFA verification failed", "error", err)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "MFA verification failed",
+			"message": "Invalid or expired code",
+		})
+		return
+	}
+	h.authAttempts.WithLabelValues("success", "totp").Inc()
+
+	h.issueTokensForMFALogin(c, user)
+}
+
+// MFARecovery completes a login using a one-time recovery code in place of
+// a TOTP code.
+func (h *AuthHandler) MFARecovery(c *gin.Context) {
+	var req models.MFARecoveryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Validation failed",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	user, err := h.authService.VerifyMFARecovery(req.MFAToken, req.RecoveryCode)
+	if err != nil {
+		logging.FromContext(c.Request.Context()).Warn("MFA recovery failed", "error", err)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "MFA recovery failed",
+			"message": "Invalid or already used recovery code",
+		})
+		return
+	}
+
+	h.issueTokensForMFALogin(c, user)
+}
+
+func (h *AuthHandler) issueTokensForMFALogin(c *gin.Context, user *models.User) {
+	authResponse, err := h.authService.GenerateTokens(user, c.GetHeader("User-Agent"), c.ClientIP())
+	if err != nil {
+		logging.FromContext(c.Request.Context()).Error("Token generation failed", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Login successful but token generation failed",
+			"message": "Please try again",
+		})
+		return
+	}
+
+	if err := h.authService.UpdateLastLogin(user.ID); err != nil {
+		logging.FromContext(c.Request.Context()).Warn("Failed to update last login time", "error", err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":      "Login successful",
+		"token":        authResponse.Token,
+		"refreshToken": authResponse.RefreshToken,
+		"user":         user,
+		"expiresIn":    authResponse.ExpiresIn,
+		"expiresAt":    authResponse.ExpiresAt,
+	})
+}
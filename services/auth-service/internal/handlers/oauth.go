@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/oauth2"
+
+	"auth-service/internal/logging"
+)
+
+const (
+	oauthStateCookie    = "oauth_state"
+	oauthVerifierCookie = "oauth_verifier"
+)
+
+// OAuthStart redirects the user to the provider's consent screen, stashing
+// a random state value and a PKCE code verifier in short-lived cookies to
+// be checked on callback.
+func (h *AuthHandler) OAuthStart(c *gin.Context) {
+	provider := c.Param("provider")
+
+	p, ok := h.authService.OAuthProvider(provider)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Unknown provider",
+			"message": "No OAuth provider is configured with that name",
+		})
+		return
+	}
+
+	state, err := generateOAuthState()
+	if err != nil {
+		logging.FromContext(c.Request.Context()).Error("Failed to generate oauth state", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to start OAuth login",
+		})
+		return
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	c.SetCookie(oauthStateCookie, state, 300, "/", "", false, true)
+	c.SetCookie(oauthVerifierCookie, verifier, 300, "/", "", false, true)
+	c.Redirect(http.StatusFound, p.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier)))
+}
+
+// OAuthCallback completes the ceremony: it validates the state cookie,
+// exchanges the code (with the matching PKCE verifier) for an identity,
+// JIT-provisions/links the user, and issues a normal token pair.
+func (h *AuthHandler) OAuthCallback(c *gin.Context) {
+	provider := c.Param("provider")
+	code := c.Query("code")
+	state := c.Query("state")
+
+	expectedState, err := c.Cookie(oauthStateCookie)
+	if err != nil || state == "" || state != expectedState {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid OAuth state",
+			"message": "Please restart the login",
+		})
+		return
+	}
+	c.SetCookie(oauthStateCookie, "", -1, "/", "", false, true)
+
+	verifier, _ := c.Cookie(oauthVerifierCookie)
+	c.SetCookie(oauthVerifierCookie, "", -1, "/", "", false, true)
+
+	user, err := h.authService.OAuthLogin(c.Request.Context(), provider, code, verifier)
+	if err != nil {
+		logging.FromContext(c.Request.Context()).Warn("OAuth login failed", "error", err, "provider", provider)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "OAuth login failed",
+			"message": "Unable to complete login with that provider",
+		})
+		return
+	}
+
+	authResponse, err := h.authService.GenerateTokens(user, c.GetHeader("User-Agent"), c.ClientIP())
+	if err != nil {
+		logging.FromContext(c.Request.Context()).Error("Token generation failed", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Login successful but token generation failed",
+		})
+		return
+	}
+
+	logging.FromContext(c.Request.Context()).Info("User logged in successfully", "user_id", user.ID, "provider", provider)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":      "Login successful",
+		"token":        authResponse.Token,
+		"refreshToken": authResponse.RefreshToken,
+		"user":         user,
+		"expiresIn":    authResponse.ExpiresIn,
+		"expiresAt":    authResponse.ExpiresAt,
+	})
+}
+
+func generateOAuthState() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
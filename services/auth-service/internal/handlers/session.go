@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"auth-service/internal/logging"
+)
+
+// ListSessions returns the caller's active (non-revoked) login sessions.
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	user, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.authService.ListSessions(user.ID)
+	if err != nil {
+		logging.FromContext(c.Request.Context()).Error("Failed to list sessions", "error", err, "user_id", user.ID)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to list sessions",
+			"message": "Please try again",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"sessions": sessions,
+	})
+}
+
+// RevokeSession ends one of the caller's own sessions, e.g. "log out this device".
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	user, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	sid, err := uuid.Parse(c.Param("sid"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid session ID",
+			"message": "Session ID must be a valid UUID",
+		})
+		return
+	}
+
+	if err := h.authService.RevokeSession(user.ID, sid); err != nil {
+		logging.FromContext(c.Request.Context()).Warn("Failed to revoke session", "error", err, "user_id", user.ID, "session_id", sid)
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Session not found",
+			"message": "Unable to revoke session",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Session revoked",
+	})
+}
+
+// RevokeAllSessions ends every session for the caller, i.e. "log out everywhere".
+func (h *AuthHandler) RevokeAllSessions(c *gin.Context) {
+	user, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.authService.RevokeAllSessions(user.ID); err != nil {
+		logging.FromContext(c.Request.Context()).Error("Failed to revoke all sessions", "error", err, "user_id", user.ID)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to revoke sessions",
+			"message": "Please try again",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "All sessions revoked",
+	})
+}
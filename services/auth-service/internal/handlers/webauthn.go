@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"auth-service/internal/logging"
+	"auth-service/internal/models"
+)
+
+// WebAuthnRegisterBegin starts a passkey registration ceremony for the
+// authenticated user.
+func (h *AuthHandler) WebAuthnRegisterBegin(c *gin.Context) {
+	user, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.authService.BeginRegistration(user)
+	if err != nil {
+		logging.FromContext(c.Request.Context()).Error("Failed to begin webauthn registration", "error", err, "user_id", user.ID)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to start passkey registration",
+			"message": "Please try again",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// WebAuthnRegisterFinish completes a passkey registration. The browser's
+// navigator.credentials.create() result is sent as the raw request body,
+// which the webauthn library parses directly off c.Request.
+func (h *AuthHandler) WebAuthnRegisterFinish(c *gin.Context) {
+	user, ok := h.currentUser(c)
+	if !ok {
+		return
+	}
+
+	challengeID := c.Query("challengeId")
+	if challengeID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Missing challengeId",
+			"message": "challengeId query parameter is required",
+		})
+		return
+	}
+
+	cred, err := h.authService.FinishRegistration(user, challengeID, c.Request)
+	if err != nil {
+		h.authAttempts.WithLabelValues("failure", "webauthn").Inc()
+		logging.FromContext(c.Request.Context()).Warn("Webauthn registration failed", "error", err, "user_id", user.ID)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Passkey registration failed",
+			"message": "Could not verify the new passkey",
+		})
+		return
+	}
+
+	h.authAttempts.WithLabelValues("success", "webauthn").Inc()
+	c.JSON(http.StatusOK, cred)
+}
+
+// WebAuthnLoginBegin starts a passkey login ceremony. An empty email starts
+// a discoverable-credential (passwordless) flow.
+func (h *AuthHandler) WebAuthnLoginBegin(c *gin.Context) {
+	var req models.WebAuthnLoginBeginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	resp, err := h.authService.BeginLogin(&req)
+	if err != nil {
+		logging.FromContext(c.Request.Context()).Warn("Failed to begin webauthn login", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Failed to start passkey login",
+			"message": "Please try again",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// WebAuthnLoginFinish completes a passkey login and issues tokens, mirroring
+// the response shape of a normal password login.
+func (h *AuthHandler) WebAuthnLoginFinish(c *gin.Context) {
+	challengeID := c.Query("challengeId")
+	if challengeID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Missing challengeId",
+			"message": "challengeId query parameter is required",
+		})
+		return
+	}
+
+	user, err := h.authService.FinishLogin(challengeID, c.Request)
+	if err != nil {
+		h.authAttempts.WithLabelValues("failure", "webauthn").Inc()
+		logging.FromContext(c.Request.Context()).Warn("Webauthn login failed", "error", err)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "Passkey login failed",
+			"message": "Could not verify the passkey",
+		})
+		return
+	}
+
+	h.authAttempts.WithLabelValues("success", "webauthn").Inc()
+	h.issueTokensForMFALogin(c, user)
+}
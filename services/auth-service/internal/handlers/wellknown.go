@@ -0,0 +1,20 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JWKS serves this service's public verification keys in standard JWKS
+// form, so other services in the cluster can verify tokens without sharing
+// a secret.
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	c.JSON(http.StatusOK, h.authService.JWKS())
+}
+
+// OpenIDConfiguration serves a minimal OIDC discovery document pointing at
+// the JWKS endpoint above.
+func (h *AuthHandler) OpenIDConfiguration(c *gin.Context) {
+	c.JSON(http.StatusOK, h.authService.OpenIDConfiguration())
+}
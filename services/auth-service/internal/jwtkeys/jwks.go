@@ -0,0 +1,58 @@
+package jwtkeys
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+)
+
+// JWK is a single entry in a JSON Web Key Set, covering the RSA and OKP
+// (Ed25519) key types this package produces.
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use,omitempty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set, the standard format for publishing public
+// verification keys at a well-known URI.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the JSON Web Key Set for every known key, current and
+// previous alike, so a just-rotated-out key's already-issued tokens keep
+// verifying until they expire naturally.
+func (m *Manager) JWKS() JWKS {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	jwks := JWKS{Keys: make([]JWK, 0, len(m.keys))}
+	for _, key := range m.keys {
+		jwks.Keys = append(jwks.Keys, jwkFor(key))
+	}
+	return jwks
+}
+
+func jwkFor(key *Key) JWK {
+	jwk := JWK{Kid: key.ID, Use: "sig", Alg: key.Alg}
+
+	switch pub := key.PublicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	}
+
+	return jwk
+}
@@ -0,0 +1,253 @@
+// Package jwtkeys loads the asymmetric signing keys auth-service uses to
+// issue and verify JWTs, and exposes them in JWKS form so other services in
+// the cluster can verify tokens without sharing a secret.
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+
+	rsaKeySizeBits = 2048
+)
+
+// Key is a single signing/verification keypair identified by kid.
+type Key struct {
+	ID         string
+	Alg        string
+	PrivateKey crypto.Signer
+	PublicKey  crypto.PublicKey
+	CreatedAt  time.Time
+}
+
+// Manager holds every known signing key. New tokens are signed with the
+// current key; previously-current keys are kept around purely for
+// verification so in-flight tokens keep validating across a rotation. Rotate
+// and PruneRetired are safe to call concurrently with Keyfunc/JWKS/SigningKey.
+type Manager struct {
+	mu         sync.RWMutex
+	alg        string
+	currentKID string
+	keys       map[string]*Key
+}
+
+// Load reads every "<kid>.pem" PKCS#8 private key file in dir. If current
+// is empty, the lexicographically greatest kid is used as the signing key
+// -- callers that roll kids from a timestamp or counter get "newest wins"
+// for free; everything else is retained for verification only.
+func Load(dir, alg, current string) (*Manager, error) {
+	if alg != AlgRS256 && alg != AlgEdDSA {
+		return nil, fmt.Errorf("unsupported jwt alg: %s", alg)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jwt key directory: %w", err)
+	}
+
+	keys := make(map[string]*Key)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".pem" {
+			continue
+		}
+
+		kid := strings.TrimSuffix(entry.Name(), ".pem")
+		key, err := loadKey(filepath.Join(dir, entry.Name()), kid, alg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load jwt key %q: %w", kid, err)
+		}
+		keys[kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no jwt signing keys found in %s", dir)
+	}
+
+	if current == "" {
+		kids := make([]string, 0, len(keys))
+		for kid := range keys {
+			kids = append(kids, kid)
+		}
+		sort.Strings(kids)
+		current = kids[len(kids)-1]
+	}
+
+	if _, ok := keys[current]; !ok {
+		return nil, fmt.Errorf("current jwt key id %q not found in %s", current, dir)
+	}
+
+	return &Manager{alg: alg, currentKID: current, keys: keys}, nil
+}
+
+func loadKey(path, kid, alg string) (*Key, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, errors.New("invalid PEM data")
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	signer, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("key does not support signing")
+	}
+
+	switch alg {
+	case AlgRS256:
+		if _, ok := signer.(*rsa.PrivateKey); !ok {
+			return nil, errors.New("JWT_ALG=RS256 requires an RSA private key")
+		}
+	case AlgEdDSA:
+		if _, ok := signer.(ed25519.PrivateKey); !ok {
+			return nil, errors.New("JWT_ALG=EdDSA requires an Ed25519 private key")
+		}
+	}
+
+	return &Key{ID: kid, Alg: alg, PrivateKey: signer, PublicKey: signer.Public(), CreatedAt: time.Now()}, nil
+}
+
+// generateKey creates a brand new signing keypair in the manager's
+// configured algorithm, for use by Rotate.
+func generateKey(alg string) (crypto.Signer, error) {
+	switch alg {
+	case AlgRS256:
+		key, err := rsa.GenerateKey(rand.Reader, rsaKeySizeBits)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate rsa key: %w", err)
+		}
+		return key, nil
+	case AlgEdDSA:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate ed25519 key: %w", err)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported jwt alg: %s", alg)
+	}
+}
+
+func newKID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Rotate generates a new signing key and makes it the current one. Previous
+// keys are left in place for verification; call PruneRetired to drop the
+// ones old enough that no in-flight token could still reference them.
+func (m *Manager) Rotate() (string, error) {
+	signer, err := generateKey(m.alg)
+	if err != nil {
+		return "", err
+	}
+
+	kid, err := newKID()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate key id: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.keys[kid] = &Key{ID: kid, Alg: m.alg, PrivateKey: signer, PublicKey: signer.Public(), CreatedAt: time.Now()}
+	m.currentKID = kid
+
+	return kid, nil
+}
+
+// PruneRetired removes signing keys older than maxAge, other than the
+// current one, so the JWKS doesn't grow forever. Age is counted from the
+// key's CreatedAt, not from when it stopped being current, so maxAge must
+// cover the time a key spends as the current key plus the longest lifetime
+// of any token it signed: a key must outlive every token it ever signed.
+func (m *Manager) PruneRetired(maxAge time.Duration) {
+	cutoff := time.Now().Add(-maxAge)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for kid, key := range m.keys {
+		if kid == m.currentKID {
+			continue
+		}
+		if key.CreatedAt.Before(cutoff) {
+			delete(m.keys, kid)
+		}
+	}
+}
+
+// SigningMethod returns the jwt-go signing method matching this manager's
+// configured algorithm.
+func (m *Manager) SigningMethod() jwt.SigningMethod {
+	if m.alg == AlgEdDSA {
+		return jwt.SigningMethodEdDSA
+	}
+	return jwt.SigningMethodRS256
+}
+
+// CurrentKID returns the kid of the key new tokens are signed with.
+func (m *Manager) CurrentKID() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.currentKID
+}
+
+// SigningKey returns the private key new tokens are signed with.
+func (m *Manager) SigningKey() crypto.Signer {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.keys[m.currentKID].PrivateKey
+}
+
+// Keyfunc resolves the verification key for a token from its kid header,
+// accepting the current key and any retained previous key alike. Pass this
+// directly to jwt.Parse / jwt.ParseWithClaims.
+func (m *Manager) Keyfunc(token *jwt.Token) (interface{}, error) {
+	if token.Method.Alg() != m.alg {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	kid, ok := token.Header["kid"].(string)
+	if !ok {
+		return nil, errors.New("token missing kid header")
+	}
+
+	m.mu.RLock()
+	key, ok := m.keys[kid]
+	m.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown key id: %s", kid)
+	}
+
+	return key.PublicKey, nil
+}
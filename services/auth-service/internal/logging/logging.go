@@ -0,0 +1,55 @@
+// Package logging configures the service's structured logger and threads a
+// request-scoped *slog.Logger through context.Context so a single request
+// produces a coherent, correlated trace across middleware, handlers and
+// services.
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"auth-service/internal/config"
+)
+
+type contextKey struct{}
+
+// Init configures the process-wide default logger: JSON output in
+// production (machine-parseable for log aggregation) and human-readable
+// text output everywhere else, at the level requested by cfg.LogLevel.
+func Init(cfg *config.Config) {
+	opts := &slog.HandlerOptions{Level: parseLevel(cfg.LogLevel)}
+
+	var handler slog.Handler
+	if cfg.Environment == "production" {
+		handler = slog.NewJSONHandler(os.Stdout, opts)
+	} else {
+		handler = slog.NewTextHandler(os.Stdout, opts)
+	}
+
+	slog.SetDefault(slog.New(handler))
+}
+
+func parseLevel(level string) slog.Level {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return slog.LevelInfo
+	}
+	return l
+}
+
+// IntoContext returns a copy of ctx carrying logger, retrievable via
+// FromContext.
+func IntoContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the logger stored by IntoContext, pre-populated with
+// this request's correlation fields. Call sites outside a request (startup,
+// background jobs) won't find one and get slog.Default() instead.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
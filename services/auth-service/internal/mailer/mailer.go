@@ -0,0 +1,28 @@
+// Package mailer sends transactional emails for the auth service (currently
+// just password reset links) behind a small interface so the SMTP
+// implementation can be swapped for a logging stub in development.
+package mailer
+
+import "log/slog"
+
+// Mailer sends an email with both plain-text and HTML bodies.
+type Mailer interface {
+	Send(to, subject, textBody, htmlBody string) error
+}
+
+// LogMailer writes emails to the log instead of sending them, for local
+// development where no SMTP server is configured.
+type LogMailer struct{}
+
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+func (m *LogMailer) Send(to, subject, textBody, htmlBody string) error {
+	slog.Info("Email not sent (log mailer): no SMTP configured",
+		"to", to,
+		"subject", subject,
+		"body", textBody,
+	)
+	return nil
+}
@@ -0,0 +1,46 @@
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+)
+
+var passwordResetTextTemplate = texttemplate.Must(texttemplate.New("password_reset_text").Parse(
+	`Hi,
+
+We received a request to reset your password. Use the link below within {{.TTLMinutes}} minutes to choose a new one:
+
+{{.ResetLink}}
+
+If you didn't request this, you can safely ignore this email.
+`))
+
+var passwordResetHTMLTemplate = htmltemplate.Must(htmltemplate.New("password_reset_html").Parse(
+	`<p>Hi,</p>
+<p>We received a request to reset your password. Use the link below within {{.TTLMinutes}} minutes to choose a new one:</p>
+<p><a href="{{.ResetLink}}">Reset your password</a></p>
+<p>If you didn't request this, you can safely ignore this email.</p>
+`))
+
+type passwordResetEmailData struct {
+	ResetLink  string
+	TTLMinutes int
+}
+
+// RenderPasswordResetEmail builds the subject plus plain-text and HTML
+// bodies for a password reset email.
+func RenderPasswordResetEmail(resetLink string, ttlMinutes int) (subject, textBody, htmlBody string, err error) {
+	data := passwordResetEmailData{ResetLink: resetLink, TTLMinutes: ttlMinutes}
+
+	var textBuf, htmlBuf bytes.Buffer
+	if err := passwordResetTextTemplate.Execute(&textBuf, data); err != nil {
+		return "", "", "", fmt.Errorf("failed to render password reset text email: %w", err)
+	}
+	if err := passwordResetHTMLTemplate.Execute(&htmlBuf, data); err != nil {
+		return "", "", "", fmt.Errorf("failed to render password reset html email: %w", err)
+	}
+
+	return "Reset your password", textBuf.String(), htmlBuf.String(), nil
+}
@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"auth-service/internal/logging"
+	"auth-service/internal/models"
+	"auth-service/internal/rbac"
+)
+
+const contextUserKey = "auth_user"
+
+// TokenVerifier is satisfied by *service.AuthService. It's expressed as an
+// interface here so middleware doesn't need to import the service package.
+type TokenVerifier interface {
+	VerifyToken(token string) (*models.User, error)
+}
+
+// Authenticate requires a valid bearer access token, re-fetching the user
+// from the database (via VerifyToken) on every request so a role change or
+// deactivation takes effect immediately. On success the user is stored on
+// the gin context for CurrentUser, RequireRole and RequirePermission.
+func Authenticate(verifier TokenVerifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "Authorization header required",
+				"message": "Please provide a valid token",
+			})
+			c.Abort()
+			return
+		}
+
+		token := strings.TrimPrefix(authHeader, "Bearer ")
+
+		user, err := verifier.VerifyToken(token)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "Invalid token",
+				"message": "Please login again",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Set(contextUserKey, user)
+
+		logger := logging.FromContext(c.Request.Context()).With("user_id", user.ID)
+		c.Request = c.Request.WithContext(logging.IntoContext(c.Request.Context(), logger))
+
+		c.Next()
+	}
+}
+
+// CurrentUser returns the user stored by Authenticate, if any.
+func CurrentUser(c *gin.Context) (*models.User, bool) {
+	value, ok := c.Get(contextUserKey)
+	if !ok {
+		return nil, false
+	}
+
+	user, ok := value.(*models.User)
+	return user, ok
+}
+
+// RequireRole allows the request through only if the authenticated user's
+// role is at least as privileged as one of the given roles. Must run after
+// Authenticate.
+func RequireRole(roles ...rbac.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := CurrentUser(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "Authentication required",
+				"message": "Please login",
+			})
+			c.Abort()
+			return
+		}
+
+		userRole := rbac.Role(user.Role)
+		for _, role := range roles {
+			if userRole.AtLeast(role) {
+				c.Next()
+				return
+			}
+		}
+
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "Insufficient permissions",
+			"message": "You don't have access to this resource",
+		})
+		c.Abort()
+	}
+}
+
+// RequirePermission allows the request through only if the authenticated
+// user's role holds perm in registry. Must run after Authenticate.
+func RequirePermission(registry *rbac.Registry, perm rbac.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := CurrentUser(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "Authentication required",
+				"message": "Please login",
+			})
+			c.Abort()
+			return
+		}
+
+		if !registry.Has(rbac.Role(user.Role), perm) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":   "Insufficient permissions",
+				"message": "You don't have access to this resource",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -4,10 +4,13 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/sirupsen/logrus"
+
+	"auth-service/internal/logging"
 )
 
-// Logger returns a gin.HandlerFunc (middleware) that logs requests using logrus.
+// Logger returns a gin.HandlerFunc (middleware) that logs requests using the
+// request-scoped logger stashed by RequestContext, so access logs carry the
+// same request_id/trace_id as everything else that request touches.
 func Logger() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Start timer
@@ -42,13 +45,19 @@ func Logger() gin.HandlerFunc {
 			path = path + "?" + raw
 		}
 
-		logrus.WithFields(logrus.Fields{
-			"status":     param.StatusCode,
-			"latency":    param.Latency,
-			"client_ip":  param.ClientIP,
-			"method":     param.Method,
-			"path":       param.Path,
-			"body_size":  param.BodySize,
-		}).Info("HTTP Request")
+		logger := logging.FromContext(c.Request.Context())
+		args := []any{
+			"status", param.StatusCode,
+			"latency", param.Latency,
+			"client_ip", param.ClientIP,
+			"method", param.Method,
+			"path", param.Path,
+			"body_size", param.BodySize,
+		}
+		if param.ErrorMessage != "" {
+			args = append(args, "error", param.ErrorMessage)
+		}
+
+		logger.Info("HTTP Request", args...)
 	}
-}
\ No newline at end of file
+}
@@ -6,7 +6,8 @@ import (
 	"runtime/debug"
 
 	"github.com/gin-gonic/gin"
-	"github.com/sirupsen/logrus"
+
+	"auth-service/internal/logging"
 )
 
 // Recovery returns a middleware that recovers from any panics and writes a 500 if there was one.
@@ -15,17 +16,17 @@ func Recovery() gin.HandlerFunc {
 		defer func() {
 			if err := recover(); err != nil {
 				// Log the panic
-				logrus.WithFields(logrus.Fields{
-					"error":      fmt.Sprintf("%v", err),
-					"stack":      string(debug.Stack()),
-					"path":       c.Request.URL.Path,
-					"method":     c.Request.Method,
-					"client_ip":  c.ClientIP(),
-				}).Error("Panic recovered")
+				logging.FromContext(c.Request.Context()).Error("Panic recovered",
+					"error", fmt.Sprintf("%v", err),
+					"stack", string(debug.Stack()),
+					"path", c.Request.URL.Path,
+					"method", c.Request.Method,
+					"client_ip", c.ClientIP(),
+				)
 
 				// Return error response
 				c.JSON(http.StatusInternalServerError, gin.H{
-					"error": "Internal server error",
+					"error":  "Internal server error",
 					"status": "error",
 				})
 				c.Abort()
@@ -33,4 +34,4 @@ func Recovery() gin.HandlerFunc {
 		}()
 		c.Next()
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"auth-service/internal/logging"
+)
+
+const (
+	requestIDHeader   = "X-Request-ID"
+	traceparentHeader = "traceparent"
+)
+
+// RequestContext reads or generates a request ID and a W3C traceparent for
+// every request, builds a *slog.Logger carrying them plus the client IP and
+// matched route, and stores it on the request context so Logger, Recovery
+// and handlers can all log with the same correlation fields via
+// logging.FromContext.
+func RequestContext() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(requestIDHeader)
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
+		c.Writer.Header().Set(requestIDHeader, requestID)
+
+		traceID := parseTraceparent(c.GetHeader(traceparentHeader))
+		if traceID == "" {
+			var err error
+			traceID, err = newHexID(16)
+			if err != nil {
+				traceID = requestID
+			}
+		}
+
+		logger := logging.FromContext(c.Request.Context()).With(
+			"request_id", requestID,
+			"trace_id", traceID,
+			"client_ip", c.ClientIP(),
+			"route", c.FullPath(),
+		)
+
+		c.Request = c.Request.WithContext(logging.IntoContext(c.Request.Context(), logger))
+		c.Next()
+	}
+}
+
+// parseTraceparent extracts the trace ID from a W3C traceparent header
+// ("version-traceid-spanid-flags"), returning "" if the header is absent or
+// malformed -- a missing/bad traceparent just means this request starts a
+// new trace rather than continuing an upstream one.
+func parseTraceparent(header string) string {
+	if header == "" {
+		return ""
+	}
+
+	parts := strings.Split(header, "-")
+	if len(parts) != 4 || len(parts[1]) != 32 {
+		return ""
+	}
+
+	return parts[1]
+}
+
+func newHexID(bytes int) (string, error) {
+	b := make([]byte, bytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -6,6 +6,12 @@ import (
 	"github.com/google/uuid"
 )
 
+// Login types a user can authenticate with.
+const (
+	LoginTypePassword = "password"
+	LoginTypeOIDC     = "oidc"
+)
+
 type User struct {
 	ID           uuid.UUID  `json:"id" db:"id"`
 	Email        string     `json:"email" db:"email" validate:"required,email"`
@@ -13,6 +19,7 @@ type User struct {
 	FirstName    *string    `json:"firstName,omitempty" db:"first_name"`
 	LastName     *string    `json:"lastName,omitempty" db:"last_name"`
 	Role         string     `json:"role" db:"role"`
+	LoginType    string     `json:"loginType" db:"login_type"`
 	IsActive     bool       `json:"isActive" db:"is_active"`
 	CreatedAt    time.Time  `json:"createdAt" db:"created_at"`
 	UpdatedAt    time.Time  `json:"updatedAt" db:"updated_at"`
@@ -27,9 +34,12 @@ type RegisterRequest struct {
 	LastName        *string `json:"lastName,omitempty"`
 }
 
+// LoginRequest.Password is optional: an account with a registered passkey
+// rejects a password entirely (see AuthService.Login / ErrWebAuthnRequired),
+// so email-only requests are valid and start a WebAuthn ceremony instead.
 type LoginRequest struct {
 	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required"`
+	Password string `json:"password"`
 }
 
 type RefreshTokenRequest struct {
@@ -40,6 +50,10 @@ type VerifyTokenRequest struct {
 	Token string `json:"token" validate:"required"`
 }
 
+type LogoutRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
 type ForgotPasswordRequest struct {
 	Email string `json:"email" validate:"required,email"`
 }
@@ -74,19 +88,141 @@ type VerifyTokenResponse struct {
 }
 
 type RefreshToken struct {
-	ID        uuid.UUID `json:"id" db:"id"`
-	UserID    uuid.UUID `json:"userId" db:"user_id"`
-	Token     string    `json:"token" db:"token"`
-	ExpiresAt time.Time `json:"expiresAt" db:"expires_at"`
-	CreatedAt time.Time `json:"createdAt" db:"created_at"`
-	IsRevoked bool      `json:"isRevoked" db:"is_revoked"`
+	ID        uuid.UUID  `json:"id" db:"id"`
+	UserID    uuid.UUID  `json:"userId" db:"user_id"`
+	TokenHash string     `json:"-" db:"token_hash"`
+	ExpiresAt time.Time  `json:"expiresAt" db:"expires_at"`
+	CreatedAt time.Time  `json:"createdAt" db:"created_at"`
+	RevokedAt *time.Time `json:"revokedAt,omitempty" db:"revoked_at"`
+	IsRevoked bool       `json:"isRevoked" db:"is_revoked"`
+}
+
+type UserLink struct {
+	ID              uuid.UUID `json:"id" db:"id"`
+	UserID          uuid.UUID `json:"userId" db:"user_id"`
+	Provider        string    `json:"provider" db:"provider"`
+	ProviderUserID  string    `json:"providerUserId" db:"provider_user_id"`
+	AccessTokenEnc  *string   `json:"-" db:"access_token_enc"`
+	RefreshTokenEnc *string   `json:"-" db:"refresh_token_enc"`
+	ExpiresAt       *time.Time `json:"expiresAt,omitempty" db:"expires_at"`
+	CreatedAt       time.Time `json:"createdAt" db:"created_at"`
+	UpdatedAt       time.Time `json:"updatedAt" db:"updated_at"`
+}
+
+type UserMFA struct {
+	UserID              uuid.UUID  `json:"userId" db:"user_id"`
+	SecretEnc           string     `json:"-" db:"secret_enc"`
+	ConfirmedAt         *time.Time `json:"confirmedAt,omitempty" db:"confirmed_at"`
+	RecoveryCodesHashed []string   `json:"-" db:"recovery_codes_hashed"`
+	CreatedAt           time.Time  `json:"createdAt" db:"created_at"`
+	UpdatedAt           time.Time  `json:"updatedAt" db:"updated_at"`
+}
+
+type MFAEnrollResponse struct {
+	OTPAuthURI  string `json:"otpauthUri"`
+	QRCodePNG   string `json:"qrCodePng"`
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type MFAConfirmRequest struct {
+	Code string `json:"code" validate:"required,len=6"`
+}
+
+type MFAVerifyRequest struct {
+	MFAToken string `json:"mfaToken" validate:"required"`
+	Code     string `json:"code" validate:"required,len=6"`
+}
+
+type MFARecoveryRequest struct {
+	MFAToken     string `json:"mfaToken" validate:"required"`
+	RecoveryCode string `json:"recoveryCode" validate:"required"`
+}
+
+// UserListFilter narrows UserRepository.ListUsers to a subset of accounts.
+// A zero value matches every user.
+type UserListFilter struct {
+	Role     string
+	IsActive *bool
+	Search   string
+}
+
+type UserListResponse struct {
+	Users      []*User `json:"users"`
+	Page       int     `json:"page"`
+	PageSize   int     `json:"pageSize"`
+	TotalCount int64   `json:"totalCount"`
+}
+
+type UpdateRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=user admin superadmin"`
+}
+
+// WebAuthnCredential is a registered passkey/security key bound to a user.
+// CredentialID and PublicKey are the raw bytes the webauthn library works
+// with directly; the rest mirrors the flags it reports on each ceremony.
+type WebAuthnCredential struct {
+	ID              uuid.UUID  `json:"id" db:"id"`
+	UserID          uuid.UUID  `json:"userId" db:"user_id"`
+	CredentialID    []byte     `json:"-" db:"credential_id"`
+	PublicKey       []byte     `json:"-" db:"public_key"`
+	AttestationType string     `json:"attestationType" db:"attestation_type"`
+	AAGUID          []byte     `json:"-" db:"aaguid"`
+	SignCount       int64      `json:"-" db:"sign_count"`
+	Transports      []string   `json:"transports" db:"transports"`
+	UserVerified    bool       `json:"userVerified" db:"user_verified"`
+	BackupEligible  bool       `json:"-" db:"backup_eligible"`
+	BackupState     bool       `json:"-" db:"backup_state"`
+	Compromised     bool       `json:"-" db:"compromised"`
+	CreatedAt       time.Time  `json:"createdAt" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updatedAt" db:"updated_at"`
+	LastUsedAt      *time.Time `json:"lastUsedAt,omitempty" db:"last_used_at"`
+}
+
+// WebAuthnBeginResponse wraps the library's ceremony options together with
+// the challenge ID the client must echo back to the matching finish call.
+type WebAuthnBeginResponse struct {
+	ChallengeID string      `json:"challengeId"`
+	Options     interface{} `json:"options"`
+}
+
+// WebAuthnLoginBeginRequest starts a login ceremony. Email may be left blank
+// to start a discoverable-credential (resident key) passwordless flow,
+// where the authenticator itself supplies the user handle.
+type WebAuthnLoginBeginRequest struct {
+	Email string `json:"email"`
 }
 
 type PasswordResetToken struct {
-	ID        uuid.UUID `json:"id" db:"id"`
-	UserID    uuid.UUID `json:"userId" db:"user_id"`
-	Token     string    `json:"token" db:"token"`
-	ExpiresAt time.Time `json:"expiresAt" db:"expires_at"`
-	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+	ID        uuid.UUID  `json:"id" db:"id"`
+	UserID    uuid.UUID  `json:"userId" db:"user_id"`
+	TokenHash string     `json:"-" db:"token_hash"`
+	ExpiresAt time.Time  `json:"expiresAt" db:"expires_at"`
+	CreatedAt time.Time  `json:"createdAt" db:"created_at"`
 	UsedAt    *time.Time `json:"usedAt,omitempty" db:"used_at"`
-}
\ No newline at end of file
+}
+
+// Session is a logical login session, identified by the "sid" claim carried
+// in every access and refresh token issued for it. A session outlives any
+// single refresh token -- rotating the refresh token keeps the same sid --
+// so revoking it (or listing it under "my sessions") reflects one real
+// login, not one token.
+type Session struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	UserID     uuid.UUID  `json:"userId" db:"user_id"`
+	UserAgent  string     `json:"userAgent" db:"user_agent"`
+	ClientIP   string     `json:"clientIp" db:"client_ip"`
+	CreatedAt  time.Time  `json:"createdAt" db:"created_at"`
+	LastUsedAt time.Time  `json:"lastUsedAt" db:"last_used_at"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty" db:"revoked_at"`
+}
+
+// AccountLockout tracks consecutive failed login/reset attempts for an
+// identifier (normally an email), used to lock the account for an
+// exponentially increasing window once the failure threshold is reached.
+// It's internal bookkeeping, not exposed over the API.
+type AccountLockout struct {
+	Identifier   string     `db:"identifier"`
+	FailureCount int        `db:"failure_count"`
+	LockedUntil  *time.Time `db:"locked_until"`
+	UpdatedAt    time.Time  `db:"updated_at"`
+}
@@ -0,0 +1,79 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+
+	"auth-service/internal/config"
+)
+
+// githubProvider implements OIDCProvider for GitHub, which doesn't publish
+// OIDC discovery metadata, so its endpoints are hardcoded and identity comes
+// from the REST user API rather than a userinfo endpoint.
+type githubProvider struct {
+	oauth2Cfg oauth2.Config
+}
+
+func NewGitHubProvider(cfg config.OIDCProviderConfig) OIDCProvider {
+	return &githubProvider{
+		oauth2Cfg: oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Scopes:       cfg.Scopes,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  "https://github.com/login/oauth/authorize",
+				TokenURL: "https://github.com/login/oauth/access_token",
+			},
+		},
+	}
+}
+
+func (p *githubProvider) Name() string {
+	return "github"
+}
+
+func (p *githubProvider) AuthCodeURL(state string, opts ...oauth2.AuthCodeOption) string {
+	return p.oauth2Cfg.AuthCodeURL(state, opts...)
+}
+
+func (p *githubProvider) Exchange(ctx context.Context, code string, opts ...oauth2.AuthCodeOption) (*UserInfo, error) {
+	token, err := p.oauth2Cfg.Exchange(ctx, code, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange oauth code: %w", err)
+	}
+
+	client := p.oauth2Cfg.Client(ctx, token)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/user", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build github user request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch github user: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var raw struct {
+		ID    int64  `json:"id"`
+		Email string `json:"email"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to decode github user response: %w", err)
+	}
+
+	return &UserInfo{
+		ProviderUserID: fmt.Sprintf("%d", raw.ID),
+		Email:          raw.Email,
+		EmailVerified:  raw.Email != "",
+		AccessToken:    token.AccessToken,
+		RefreshToken:   token.RefreshToken,
+	}, nil
+}
@@ -0,0 +1,34 @@
+// Package oauth implements pluggable OAuth/OIDC social login providers
+// (Google, GitHub, and generic OIDC issuers) used for JIT user provisioning
+// alongside password auth.
+package oauth
+
+import (
+	"context"
+
+	"golang.org/x/oauth2"
+)
+
+// UserInfo is the normalized identity handed back by a provider after a
+// successful code exchange.
+type UserInfo struct {
+	ProviderUserID string
+	Email         string
+	EmailVerified bool
+	AccessToken   string
+	RefreshToken  string
+}
+
+// OIDCProvider is implemented by each supported OIDC/OAuth identity provider.
+type OIDCProvider interface {
+	// Name is the registry key, e.g. "google", "github".
+	Name() string
+	// AuthCodeURL returns the URL to redirect the user to in order to
+	// start the login ceremony. opts carries the PKCE code_challenge when
+	// the caller is using it.
+	AuthCodeURL(state string, opts ...oauth2.AuthCodeOption) string
+	// Exchange trades an authorization code for the user's identity. opts
+	// carries the PKCE code_verifier matching the challenge sent to
+	// AuthCodeURL, when used.
+	Exchange(ctx context.Context, code string, opts ...oauth2.AuthCodeOption) (*UserInfo, error)
+}
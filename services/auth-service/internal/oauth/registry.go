@@ -0,0 +1,45 @@
+package oauth
+
+import (
+	"fmt"
+
+	"auth-service/internal/config"
+)
+
+// Registry holds the configured OIDCProviders, keyed by name ("google",
+// "github", or a custom name for a generic OIDC issuer).
+type Registry struct {
+	providers map[string]OIDCProvider
+}
+
+// NewRegistry builds a provider for each entry in providerConfigs. GitHub is
+// special-cased since it has no OIDC discovery document; everything else
+// (including Google) goes through the generic OIDC discovery flow.
+func NewRegistry(providerConfigs []config.OIDCProviderConfig) (*Registry, error) {
+	registry := &Registry{providers: make(map[string]OIDCProvider)}
+
+	for _, pc := range providerConfigs {
+		var provider OIDCProvider
+		var err error
+
+		switch pc.Name {
+		case "github":
+			provider = NewGitHubProvider(pc)
+		default:
+			provider, err = NewGenericOIDCProvider(pc)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize oidc provider %q: %w", pc.Name, err)
+		}
+
+		registry.providers[pc.Name] = provider
+	}
+
+	return registry, nil
+}
+
+func (r *Registry) Get(name string) (OIDCProvider, bool) {
+	provider, ok := r.providers[name]
+	return provider, ok
+}
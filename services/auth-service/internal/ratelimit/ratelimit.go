@@ -0,0 +1,51 @@
+// Package ratelimit provides token-bucket rate limiting for
+// security-sensitive endpoints (password reset, login, registration) keyed
+// by an arbitrary string such as an IP address or email.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Limiter reports whether another attempt for key is allowed under a
+// limit-per-window policy.
+type Limiter interface {
+	// Allow records an attempt for key and reports whether it's within
+	// limit attempts per window. How the window is measured depends on the
+	// implementation (a refilling token bucket, or a fixed window); neither
+	// is a sliding window.
+	Allow(key string, limit int, window time.Duration) (bool, error)
+}
+
+// InMemoryLimiter is a process-local token-bucket Limiter built on
+// golang.org/x/time/rate. It doesn't share state across replicas, so it's
+// intended for local development and as the default when no shared backend
+// is configured.
+type InMemoryLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*rate.Limiter
+}
+
+func NewInMemoryLimiter() *InMemoryLimiter {
+	return &InMemoryLimiter{
+		buckets: make(map[string]*rate.Limiter),
+	}
+}
+
+func (l *InMemoryLimiter) Allow(key string, limit int, window time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		// Refill at limit tokens per window, with a burst of limit so the
+		// first `limit` attempts in a fresh window all succeed.
+		b = rate.NewLimiter(rate.Limit(float64(limit)/window.Seconds()), limit)
+		l.buckets[key] = b
+	}
+
+	return b.Allow(), nil
+}
@@ -0,0 +1,64 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const keyPrefix = "auth:ratelimit:"
+
+// RedisLimiter backs Limiter with Redis so attempt counts are shared across
+// every replica of the service. It approximates the token-bucket behaviour
+// of InMemoryLimiter with a fixed window (INCR + expire on first write),
+// which is simpler to do atomically in Redis and close enough for the
+// coarse per-IP/per-email limits this package is used for.
+type RedisLimiter struct {
+	client *redis.Client
+}
+
+// NewRedisLimiter connects to Redis using redisURL (a redis:// or rediss://
+// connection string) and the given connection pool size.
+func NewRedisLimiter(redisURL string, poolSize int, useTLS bool) (*RedisLimiter, error) {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse redis url: %w", err)
+	}
+
+	opts.PoolSize = poolSize
+	if useTLS && opts.TLSConfig == nil {
+		opts.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	client := redis.NewClient(opts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+
+	return &RedisLimiter{client: client}, nil
+}
+
+func (l *RedisLimiter) Allow(key string, limit int, window time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	fullKey := keyPrefix + key
+	count, err := l.client.Incr(ctx, fullKey).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to increment rate limit counter: %w", err)
+	}
+
+	if count == 1 {
+		if err := l.client.Expire(ctx, fullKey, window).Err(); err != nil {
+			return false, fmt.Errorf("failed to set rate limit window: %w", err)
+		}
+	}
+
+	return count <= int64(limit), nil
+}
@@ -0,0 +1,118 @@
+// Package rbac defines the role/permission model used to gate admin
+// endpoints: a small hierarchy of roles, a set of named permissions, and a
+// registry mapping roles to the permissions they hold.
+package rbac
+
+// Role is a named level in the auth-service role hierarchy. Roles are
+// stored on models.User.Role as plain strings so the database schema
+// doesn't need to change when a role is added.
+type Role string
+
+const (
+	RoleUser       Role = "user"
+	RoleAdmin      Role = "admin"
+	RoleSuperAdmin Role = "superadmin"
+)
+
+// roleRank orders roles from least to most privileged. A higher rank
+// implies every permission granted to the roles below it.
+var roleRank = map[Role]int{
+	RoleUser:       0,
+	RoleAdmin:      1,
+	RoleSuperAdmin: 2,
+}
+
+// Valid reports whether r is a known role.
+func (r Role) Valid() bool {
+	_, ok := roleRank[r]
+	return ok
+}
+
+// AtLeast reports whether r is at least as privileged as other in the role
+// hierarchy. An unknown role is never at least as privileged as anything.
+func (r Role) AtLeast(other Role) bool {
+	rank, ok := roleRank[r]
+	if !ok {
+		return false
+	}
+	otherRank, ok := roleRank[other]
+	if !ok {
+		return false
+	}
+	return rank >= otherRank
+}
+
+// Outranks reports whether r is strictly more privileged than other in the
+// role hierarchy. An unknown role never outranks anything.
+func (r Role) Outranks(other Role) bool {
+	rank, ok := roleRank[r]
+	if !ok {
+		return false
+	}
+	otherRank, ok := roleRank[other]
+	if !ok {
+		return false
+	}
+	return rank > otherRank
+}
+
+// Permission is a named action that can be granted to a Role.
+type Permission string
+
+const (
+	PermissionUsersRead   Permission = "users:read"
+	PermissionUsersWrite  Permission = "users:write"
+	PermissionUsersManage Permission = "users:manage"
+)
+
+// Registry maps roles to the permission sets they hold.
+type Registry struct {
+	permissions map[Role]map[Permission]bool
+}
+
+// NewRegistry builds a Registry from a role -> permission-name mapping,
+// typically loaded from config so deployments can adjust grants without a
+// code change. Unknown roles or permission names are ignored rather than
+// treated as an error, since config is expected to evolve independently of
+// the binary.
+func NewRegistry(rolePermissions map[string][]string) *Registry {
+	permissions := make(map[Role]map[Permission]bool)
+	for role := range roleRank {
+		permissions[role] = make(map[Permission]bool)
+	}
+
+	for roleName, perms := range rolePermissions {
+		role := Role(roleName)
+		if !role.Valid() {
+			continue
+		}
+		for _, perm := range perms {
+			permissions[role][Permission(perm)] = true
+		}
+	}
+
+	return &Registry{permissions: permissions}
+}
+
+// DefaultRegistry returns the built-in grants used when no ROLE_PERMISSIONS
+// config is supplied: admins and superadmins can manage users, plain users
+// can't.
+func DefaultRegistry() *Registry {
+	return NewRegistry(map[string][]string{
+		string(RoleAdmin): {
+			string(PermissionUsersRead),
+			string(PermissionUsersWrite),
+			string(PermissionUsersManage),
+		},
+		string(RoleSuperAdmin): {
+			string(PermissionUsersRead),
+			string(PermissionUsersWrite),
+			string(PermissionUsersManage),
+		},
+	})
+}
+
+// Has reports whether role holds perm.
+func (reg *Registry) Has(role Role, perm Permission) bool {
+	return reg.permissions[role][perm]
+}
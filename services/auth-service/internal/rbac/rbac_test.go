@@ -0,0 +1,63 @@
+package rbac
+
+import "testing"
+
+func TestRole_Outranks(t *testing.T) {
+	tests := []struct {
+		name string
+		r    Role
+		over Role
+		want bool
+	}{
+		{"superadmin outranks admin", RoleSuperAdmin, RoleAdmin, true},
+		{"admin outranks user", RoleAdmin, RoleUser, true},
+		{"admin does not outrank admin", RoleAdmin, RoleAdmin, false},
+		{"admin does not outrank superadmin", RoleAdmin, RoleSuperAdmin, false},
+		{"unknown role outranks nothing", Role("bogus"), RoleUser, false},
+		{"nothing outranks an unknown role", RoleSuperAdmin, Role("bogus"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.Outranks(tt.over); got != tt.want {
+				t.Errorf("%s.Outranks(%s) = %v, want %v", tt.r, tt.over, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRole_AtLeast(t *testing.T) {
+	tests := []struct {
+		name  string
+		r     Role
+		other Role
+		want  bool
+	}{
+		{"admin at least admin", RoleAdmin, RoleAdmin, true},
+		{"superadmin at least admin", RoleSuperAdmin, RoleAdmin, true},
+		{"user not at least admin", RoleUser, RoleAdmin, false},
+		{"unknown role is never at least anything", Role("bogus"), RoleUser, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.AtLeast(tt.other); got != tt.want {
+				t.Errorf("%s.AtLeast(%s) = %v, want %v", tt.r, tt.other, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultRegistry_GrantsUsersManageToAdminAndSuperAdmin(t *testing.T) {
+	reg := DefaultRegistry()
+
+	for _, role := range []Role{RoleAdmin, RoleSuperAdmin} {
+		if !reg.Has(role, PermissionUsersManage) {
+			t.Errorf("expected %s to hold %s", role, PermissionUsersManage)
+		}
+	}
+
+	if reg.Has(RoleUser, PermissionUsersManage) {
+		t.Errorf("expected %s not to hold %s", RoleUser, PermissionUsersManage)
+	}
+}
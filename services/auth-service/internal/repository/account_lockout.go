@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"auth-service/internal/models"
+)
+
+type AccountLockoutRepository struct {
+	db *sql.DB
+}
+
+func NewAccountLockoutRepository(db *sql.DB) *AccountLockoutRepository {
+	return &AccountLockoutRepository{
+		db: db,
+	}
+}
+
+// RecordFailure increments the failure count for identifier and, once it
+// reaches threshold, locks the account for baseWindow * 2^(failures-threshold)
+// so repeated lockouts back off exponentially rather than repeating the same
+// short window. The returned AccountLockout reflects the state after this
+// failure; callers can check LockedUntil to see whether this call just
+// triggered a new lockout.
+func (r *AccountLockoutRepository) RecordFailure(identifier string, threshold int, baseWindow time.Duration) (*models.AccountLockout, error) {
+	query := `
+		INSERT INTO account_lockouts (identifier, failure_count, updated_at)
+		VALUES ($1, 1, $2)
+		ON CONFLICT (identifier) DO UPDATE
+		SET failure_count = account_lockouts.failure_count + 1,
+		    updated_at = $2
+		RETURNING failure_count`
+
+	lockout := &models.AccountLockout{Identifier: identifier, UpdatedAt: time.Now().UTC()}
+	if err := r.db.QueryRow(query, identifier, lockout.UpdatedAt).Scan(&lockout.FailureCount); err != nil {
+		slog.Error("Failed to record account lockout failure", "error", err, "identifier", identifier)
+		return nil, fmt.Errorf("failed to record login failure: %w", err)
+	}
+
+	if lockout.FailureCount >= threshold {
+		backoff := baseWindow * time.Duration(math.Pow(2, float64(lockout.FailureCount-threshold)))
+		lockedUntil := time.Now().UTC().Add(backoff)
+
+		if _, err := r.db.Exec(`UPDATE account_lockouts SET locked_until = $1 WHERE identifier = $2`, lockedUntil, identifier); err != nil {
+			slog.Error("Failed to set account lockout window", "error", err, "identifier", identifier)
+			return nil, fmt.Errorf("failed to lock account: %w", err)
+		}
+		lockout.LockedUntil = &lockedUntil
+	}
+
+	return lockout, nil
+}
+
+// IsLocked reports whether identifier is currently locked out, and until
+// when. A lock that has already expired is reported as not locked.
+func (r *AccountLockoutRepository) IsLocked(identifier string) (bool, time.Time, error) {
+	var lockedUntil sql.NullTime
+
+	query := `SELECT locked_until FROM account_lockouts WHERE identifier = $1`
+	err := r.db.QueryRow(query, identifier).Scan(&lockedUntil)
+	if err == sql.ErrNoRows {
+		return false, time.Time{}, nil
+	}
+	if err != nil {
+		slog.Error("Failed to check account lockout", "error", err, "identifier", identifier)
+		return false, time.Time{}, fmt.Errorf("failed to check account lockout: %w", err)
+	}
+
+	if !lockedUntil.Valid || lockedUntil.Time.Before(time.Now().UTC()) {
+		return false, time.Time{}, nil
+	}
+
+	return true, lockedUntil.Time, nil
+}
+
+// Reset clears identifier's failure count and lock, called after a
+// successful login/reset so it starts counting fresh on the next failure.
+func (r *AccountLockoutRepository) Reset(identifier string) error {
+	query := `DELETE FROM account_lockouts WHERE identifier = $1`
+
+	if _, err := r.db.Exec(query, identifier); err != nil {
+		slog.Error("Failed to reset account lockout", "error", err, "identifier", identifier)
+		return fmt.Errorf("failed to reset account lockout: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"auth-service/internal/models"
+)
+
+type CredentialRepository struct {
+	db *sql.DB
+}
+
+func NewCredentialRepository(db *sql.DB) *CredentialRepository {
+	return &CredentialRepository{db: db}
+}
+
+// Create persists a newly registered passkey.
+func (r *CredentialRepository) Create(cred *models.WebAuthnCredential) (*models.WebAuthnCredential, error) {
+	query := `
+		INSERT INTO webauthn_credentials
+			(user_id, credential_id, public_key, attestation_type, aaguid, sign_count, transports, user_verified, backup_eligible, backup_state, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $11)
+		RETURNING id, created_at, updated_at`
+
+	now := time.Now().UTC()
+	err := r.db.QueryRow(
+		query,
+		cred.UserID,
+		cred.CredentialID,
+		cred.PublicKey,
+		cred.AttestationType,
+		cred.AAGUID,
+		cred.SignCount,
+		pq.Array(cred.Transports),
+		cred.UserVerified,
+		cred.BackupEligible,
+		cred.BackupState,
+		now,
+	).Scan(&cred.ID, &cred.CreatedAt, &cred.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return nil, errors.New("credential already registered")
+		}
+		slog.Error("Failed to create webauthn credential", "error", err, "user_id", cred.UserID)
+		return nil, fmt.Errorf("failed to create webauthn credential: %w", err)
+	}
+
+	return cred, nil
+}
+
+// GetByUserID returns a user's active (non-compromised) passkeys, the set
+// the library needs to build ceremony options and check an assertion
+// against.
+func (r *CredentialRepository) GetByUserID(userID uuid.UUID) ([]*models.WebAuthnCredential, error) {
+	query := `
+		SELECT id, user_id, credential_id, public_key, attestation_type, aaguid, sign_count, transports,
+		       user_verified, backup_eligible, backup_state, compromised, created_at, updated_at, last_used_at
+		FROM webauthn_credentials
+		WHERE user_id = $1 AND compromised = false`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		slog.Error("Failed to list webauthn credentials", "error", err, "user_id", userID)
+		return nil, fmt.Errorf("failed to list webauthn credentials: %w", err)
+	}
+	defer rows.Close()
+
+	var credentials []*models.WebAuthnCredential
+	for rows.Next() {
+		cred, err := scanCredential(rows)
+		if err != nil {
+			return nil, err
+		}
+		credentials = append(credentials, cred)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read webauthn credential rows: %w", err)
+	}
+
+	return credentials, nil
+}
+
+// GetByCredentialID looks up a passkey by the raw credential ID reported in
+// an assertion, regardless of which user it belongs to -- needed for the
+// discoverable-credential (empty username) login flow.
+func (r *CredentialRepository) GetByCredentialID(credentialID []byte) (*models.WebAuthnCredential, error) {
+	query := `
+		SELECT id, user_id, credential_id, public_key, attestation_type, aaguid, sign_count, transports,
+		       user_verified, backup_eligible, backup_state, compromised, created_at, updated_at, last_used_at
+		FROM webauthn_credentials
+		WHERE credential_id = $1`
+
+	cred, err := scanCredential(r.db.QueryRow(query, credentialID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("credential not found")
+		}
+		slog.Error("Failed to get webauthn credential", "error", err)
+		return nil, fmt.Errorf("failed to get webauthn credential: %w", err)
+	}
+
+	return cred, nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanCredential(row rowScanner) (*models.WebAuthnCredential, error) {
+	cred := &models.WebAuthnCredential{}
+	var transports pq.StringArray
+	if err := row.Scan(
+		&cred.ID, &cred.UserID, &cred.CredentialID, &cred.PublicKey, &cred.AttestationType,
+		&cred.AAGUID, &cred.SignCount, &transports, &cred.UserVerified, &cred.BackupEligible,
+		&cred.BackupState, &cred.Compromised, &cred.CreatedAt, &cred.UpdatedAt, &cred.LastUsedAt,
+	); err != nil {
+		return nil, err
+	}
+	cred.Transports = transports
+	return cred, nil
+}
+
+// UpdateSignCount persists a credential's new signature counter and
+// last-used timestamp after a successful assertion.
+func (r *CredentialRepository) UpdateSignCount(id uuid.UUID, signCount int64) error {
+	query := `UPDATE webauthn_credentials SET sign_count = $1, last_used_at = $2, updated_at = $2 WHERE id = $3`
+
+	now := time.Now().UTC()
+	if _, err := r.db.Exec(query, signCount, now, id); err != nil {
+		slog.Error("Failed to update webauthn sign count", "error", err, "credential_id", id)
+		return fmt.Errorf("failed to update webauthn sign count: %w", err)
+	}
+
+	return nil
+}
+
+// MarkCompromised disables a credential after a sign-count regression,
+// which indicates the authenticator may have been cloned.
+func (r *CredentialRepository) MarkCompromised(id uuid.UUID) error {
+	query := `UPDATE webauthn_credentials SET compromised = true, updated_at = $1 WHERE id = $2`
+
+	if _, err := r.db.Exec(query, time.Now().UTC(), id); err != nil {
+		slog.Error("Failed to mark webauthn credential compromised", "error", err, "credential_id", id)
+		return fmt.Errorf("failed to mark webauthn credential compromised: %w", err)
+	}
+
+	return nil
+}
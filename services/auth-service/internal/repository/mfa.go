@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"auth-service/internal/models"
+)
+
+type MFARepository struct {
+	db *sql.DB
+}
+
+func NewMFARepository(db *sql.DB) *MFARepository {
+	return &MFARepository{
+		db: db,
+	}
+}
+
+// Create stores a freshly-generated, unconfirmed TOTP secret for a user,
+// replacing any prior unconfirmed enrollment attempt.
+func (r *MFARepository) Create(userID uuid.UUID, secretEnc string, recoveryCodesHashed []string) (*models.UserMFA, error) {
+	query := `
+		INSERT INTO user_mfa (user_id, secret_enc, recovery_codes_hashed, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4)
+		ON CONFLICT (user_id) DO UPDATE
+			SET secret_enc = EXCLUDED.secret_enc,
+			    recovery_codes_hashed = EXCLUDED.recovery_codes_hashed,
+			    confirmed_at = NULL,
+			    updated_at = EXCLUDED.updated_at
+			WHERE user_mfa.confirmed_at IS NULL
+		RETURNING user_id, secret_enc, confirmed_at, recovery_codes_hashed, created_at, updated_at`
+
+	now := time.Now().UTC()
+	mfa := &models.UserMFA{}
+	var recoveryCodes pq.StringArray
+	err := r.db.QueryRow(query, userID, secretEnc, pq.Array(recoveryCodesHashed), now).Scan(
+		&mfa.UserID,
+		&mfa.SecretEnc,
+		&mfa.ConfirmedAt,
+		&recoveryCodes,
+		&mfa.CreatedAt,
+		&mfa.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("mfa already enabled")
+		}
+		slog.Error("Failed to create mfa enrollment", "error", err, "user_id", userID)
+		return nil, fmt.Errorf("failed to create mfa enrollment: %w", err)
+	}
+
+	mfa.RecoveryCodesHashed = recoveryCodes
+	return mfa, nil
+}
+
+func (r *MFARepository) GetByUserID(userID uuid.UUID) (*models.UserMFA, error) {
+	query := `
+		SELECT user_id, secret_enc, confirmed_at, recovery_codes_hashed, created_at, updated_at
+		FROM user_mfa
+		WHERE user_id = $1`
+
+	mfa := &models.UserMFA{}
+	var recoveryCodes pq.StringArray
+	err := r.db.QueryRow(query, userID).Scan(
+		&mfa.UserID,
+		&mfa.SecretEnc,
+		&mfa.ConfirmedAt,
+		&recoveryCodes,
+		&mfa.CreatedAt,
+		&mfa.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("mfa not enrolled")
+		}
+		slog.Error("Failed to get mfa enrollment", "error", err, "user_id", userID)
+		return nil, fmt.Errorf("failed to get mfa enrollment: %w", err)
+	}
+
+	mfa.RecoveryCodesHashed = recoveryCodes
+	return mfa, nil
+}
+
+func (r *MFARepository) Confirm(userID uuid.UUID) error {
+	query := `UPDATE user_mfa SET confirmed_at = $1, updated_at = $1 WHERE user_id = $2`
+
+	now := time.Now().UTC()
+	result, err := r.db.Exec(query, now, userID)
+	if err != nil {
+		return fmt.Errorf("failed to confirm mfa enrollment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.New("mfa not enrolled")
+	}
+
+	return nil
+}
+
+func (r *MFARepository) Disable(userID uuid.UUID) error {
+	query := `DELETE FROM user_mfa WHERE user_id = $1`
+
+	_, err := r.db.Exec(query, userID)
+	if err != nil {
+		return fmt.Errorf("failed to disable mfa: %w", err)
+	}
+
+	return nil
+}
+
+// ReplaceRecoveryCodes persists the remaining recovery code hashes, used
+// after one is consumed.
+func (r *MFARepository) ReplaceRecoveryCodes(userID uuid.UUID, recoveryCodesHashed []string) error {
+	query := `UPDATE user_mfa SET recovery_codes_hashed = $1, updated_at = $2 WHERE user_id = $3`
+
+	_, err := r.db.Exec(query, pq.Array(recoveryCodesHashed), time.Now().UTC(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to update recovery codes: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"auth-service/internal/models"
+)
+
+type PasswordResetRepository struct {
+	db *sql.DB
+}
+
+func NewPasswordResetRepository(db *sql.DB) *PasswordResetRepository {
+	return &PasswordResetRepository{
+		db: db,
+	}
+}
+
+func (r *PasswordResetRepository) Create(userID uuid.UUID, tokenHash string, expiresAt time.Time) (*models.PasswordResetToken, error) {
+	query := `
+		INSERT INTO password_reset_tokens (user_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`
+
+	reset := &models.PasswordResetToken{
+		UserID:    userID,
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt,
+	}
+
+	now := time.Now().UTC()
+	err := r.db.QueryRow(query, userID, tokenHash, expiresAt, now).Scan(&reset.ID, &reset.CreatedAt)
+	if err != nil {
+		slog.Error("Failed to create password reset token", "error", err, "user_id", userID)
+		return nil, fmt.Errorf("failed to create password reset token: %w", err)
+	}
+
+	return reset, nil
+}
+
+func (r *PasswordResetRepository) GetByTokenHash(tokenHash string) (*models.PasswordResetToken, error) {
+	query := `
+		SELECT id, user_id, token_hash, expires_at, created_at, used_at
+		FROM password_reset_tokens
+		WHERE token_hash = $1`
+
+	reset := &models.PasswordResetToken{}
+	err := r.db.QueryRow(query, tokenHash).Scan(
+		&reset.ID,
+		&reset.UserID,
+		&reset.TokenHash,
+		&reset.ExpiresAt,
+		&reset.CreatedAt,
+		&reset.UsedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("password reset token not found")
+		}
+		slog.Error("Failed to get password reset token by hash", "error", err)
+		return nil, fmt.Errorf("failed to get password reset token: %w", err)
+	}
+
+	return reset, nil
+}
+
+func (r *PasswordResetRepository) MarkUsed(id uuid.UUID) error {
+	query := `UPDATE password_reset_tokens SET used_at = $1 WHERE id = $2 AND used_at IS NULL`
+
+	now := time.Now().UTC()
+	result, err := r.db.Exec(query, now, id)
+	if err != nil {
+		slog.Error("Failed to mark password reset token used", "error", err, "password_reset_token_id", id)
+		return fmt.Errorf("failed to mark password reset token used: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("password reset token already used or not found")
+	}
+
+	return nil
+}
+
+// DeleteExpiredForUser removes a user's expired or already-used reset token
+// rows, keeping the table from accumulating stale entries across repeated
+// forgot-password requests.
+func (r *PasswordResetRepository) DeleteExpiredForUser(userID uuid.UUID) error {
+	query := `
+		DELETE FROM password_reset_tokens
+		WHERE user_id = $1 AND (expires_at < $2 OR used_at IS NOT NULL)`
+
+	_, err := r.db.Exec(query, userID, time.Now().UTC())
+	if err != nil {
+		slog.Error("Failed to delete expired password reset tokens", "error", err, "user_id", userID)
+		return fmt.Errorf("failed to delete expired password reset tokens: %w", err)
+	}
+
+	return nil
+}
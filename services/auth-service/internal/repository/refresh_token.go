@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"auth-service/internal/models"
+)
+
+type RefreshTokenRepository struct {
+	db *sql.DB
+}
+
+func NewRefreshTokenRepository(db *sql.DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{
+		db: db,
+	}
+}
+
+func (r *RefreshTokenRepository) Create(userID uuid.UUID, tokenHash string, expiresAt time.Time) (*models.RefreshToken, error) {
+	query := `
+		INSERT INTO refresh_tokens (user_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`
+
+	token := &models.RefreshToken{
+		UserID:    userID,
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt,
+	}
+
+	now := time.Now().UTC()
+	err := r.db.QueryRow(query, userID, tokenHash, expiresAt, now).Scan(&token.ID, &token.CreatedAt)
+	if err != nil {
+		slog.Error("Failed to create refresh token", "error", err, "user_id", userID)
+		return nil, fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	return token, nil
+}
+
+func (r *RefreshTokenRepository) GetByTokenHash(tokenHash string) (*models.RefreshToken, error) {
+	query := `
+		SELECT id, user_id, token_hash, expires_at, created_at, revoked_at, is_revoked
+		FROM refresh_tokens
+		WHERE token_hash = $1`
+
+	token := &models.RefreshToken{}
+	err := r.db.QueryRow(query, tokenHash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.CreatedAt,
+		&token.RevokedAt,
+		&token.IsRevoked,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("refresh token not found")
+		}
+		slog.Error("Failed to get refresh token by hash", "error", err)
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	return token, nil
+}
+
+func (r *RefreshTokenRepository) Revoke(id uuid.UUID) error {
+	query := `UPDATE refresh_tokens SET is_revoked = true, revoked_at = $1 WHERE id = $2`
+
+	now := time.Now().UTC()
+	result, err := r.db.Exec(query, now, id)
+	if err != nil {
+		slog.Error("Failed to revoke refresh token", "error", err, "refresh_token_id", id)
+		return fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("refresh token not found")
+	}
+
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeAllForUser(userID uuid.UUID) error {
+	query := `UPDATE refresh_tokens SET is_revoked = true, revoked_at = $1 WHERE user_id = $2 AND is_revoked = false`
+
+	now := time.Now().UTC()
+	_, err := r.db.Exec(query, now, userID)
+	if err != nil {
+		slog.Error("Failed to revoke refresh tokens for user", "error", err, "user_id", userID)
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpired removes refresh token rows that have passed their expiry and
+// returns the number of rows deleted, so callers can log purge activity.
+func (r *RefreshTokenRepository) DeleteExpired() (int64, error) {
+	query := `DELETE FROM refresh_tokens WHERE expires_at < $1`
+
+	result, err := r.db.Exec(query, time.Now().UTC())
+	if err != nil {
+		slog.Error("Failed to delete expired refresh tokens", "error", err)
+		return 0, fmt.Errorf("failed to delete expired refresh tokens: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected, nil
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"auth-service/internal/models"
+)
+
+type SessionRepository struct {
+	db *sql.DB
+}
+
+func NewSessionRepository(db *sql.DB) *SessionRepository {
+	return &SessionRepository{
+		db: db,
+	}
+}
+
+func (r *SessionRepository) Create(userID uuid.UUID, userAgent, clientIP string) (*models.Session, error) {
+	query := `
+		INSERT INTO sessions (user_id, user_agent, client_ip, created_at, last_used_at)
+		VALUES ($1, $2, $3, $4, $4)
+		RETURNING id, created_at, last_used_at`
+
+	session := &models.Session{
+		UserID:    userID,
+		UserAgent: userAgent,
+		ClientIP:  clientIP,
+	}
+
+	now := time.Now().UTC()
+	err := r.db.QueryRow(query, userID, userAgent, clientIP, now).Scan(&session.ID, &session.CreatedAt, &session.LastUsedAt)
+	if err != nil {
+		slog.Error("Failed to create session", "error", err, "user_id", userID)
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return session, nil
+}
+
+func (r *SessionRepository) GetByID(id uuid.UUID) (*models.Session, error) {
+	query := `
+		SELECT id, user_id, user_agent, client_ip, created_at, last_used_at, revoked_at
+		FROM sessions
+		WHERE id = $1`
+
+	session := &models.Session{}
+	err := r.db.QueryRow(query, id).Scan(
+		&session.ID,
+		&session.UserID,
+		&session.UserAgent,
+		&session.ClientIP,
+		&session.CreatedAt,
+		&session.LastUsedAt,
+		&session.RevokedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("session not found")
+		}
+		slog.Error("Failed to get session", "error", err, "session_id", id)
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return session, nil
+}
+
+// ListActiveByUser returns a user's non-revoked sessions, most recently used
+// first, for the "my sessions" listing endpoint.
+func (r *SessionRepository) ListActiveByUser(userID uuid.UUID) ([]*models.Session, error) {
+	query := `
+		SELECT id, user_id, user_agent, client_ip, created_at, last_used_at, revoked_at
+		FROM sessions
+		WHERE user_id = $1 AND revoked_at IS NULL
+		ORDER BY last_used_at DESC`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		slog.Error("Failed to list sessions", "error", err, "user_id", userID)
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := make([]*models.Session, 0)
+	for rows.Next() {
+		session := &models.Session{}
+		if err := rows.Scan(
+			&session.ID,
+			&session.UserID,
+			&session.UserAgent,
+			&session.ClientIP,
+			&session.CreatedAt,
+			&session.LastUsedAt,
+			&session.RevokedAt,
+		); err != nil {
+			slog.Error("Failed to scan session", "error", err)
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, rows.Err()
+}
+
+// Touch advances last_used_at, called whenever a session's refresh token is
+// used to mint a new access token.
+func (r *SessionRepository) Touch(id uuid.UUID) error {
+	query := `UPDATE sessions SET last_used_at = $1 WHERE id = $2`
+
+	if _, err := r.db.Exec(query, time.Now().UTC(), id); err != nil {
+		slog.Error("Failed to touch session", "error", err, "session_id", id)
+		return fmt.Errorf("failed to touch session: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) Revoke(id uuid.UUID) error {
+	query := `UPDATE sessions SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL`
+
+	now := time.Now().UTC()
+	result, err := r.db.Exec(query, now, id)
+	if err != nil {
+		slog.Error("Failed to revoke session", "error", err, "session_id", id)
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("session not found")
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) RevokeAllForUser(userID uuid.UUID) error {
+	query := `UPDATE sessions SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL`
+
+	now := time.Now().UTC()
+	_, err := r.db.Exec(query, now, userID)
+	if err != nil {
+		slog.Error("Failed to revoke sessions for user", "error", err, "user_id", userID)
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return nil
+}
+
+// IsRevoked reports whether id is a known, revoked session. An unknown
+// session id is treated as revoked so a dropped sessions row fails closed
+// rather than letting a stale token keep working.
+func (r *SessionRepository) IsRevoked(id uuid.UUID) (bool, error) {
+	session, err := r.GetByID(id)
+	if err != nil {
+		if err.Error() == "session not found" {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return session.RevokedAt != nil, nil
+}
@@ -4,11 +4,12 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
-	"github.com/sirupsen/logrus"
 
 	"auth-service/internal/models"
 )
@@ -25,14 +26,17 @@ func NewUserRepository(db *sql.DB) *UserRepository {
 
 func (r *UserRepository) Create(user *models.User) (*models.User, error) {
 	query := `
-		INSERT INTO users (email, password_hash, first_name, last_name, is_active, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO users (email, password_hash, first_name, last_name, login_type, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id, created_at, updated_at`
 
 	now := time.Now().UTC()
 	user.CreatedAt = now
 	user.UpdatedAt = now
 	user.IsActive = true
+	if user.LoginType == "" {
+		user.LoginType = models.LoginTypePassword
+	}
 
 	err := r.db.QueryRow(
 		query,
@@ -40,6 +44,7 @@ func (r *UserRepository) Create(user *models.User) (*models.User, error) {
 		user.PasswordHash,
 		user.FirstName,
 		user.LastName,
+		user.LoginType,
 		user.IsActive,
 		user.CreatedAt,
 		user.UpdatedAt,
@@ -52,7 +57,7 @@ func (r *UserRepository) Create(user *models.User) (*models.User, error) {
 				return nil, errors.New("user already exists")
 			}
 		}
-		logrus.WithError(err).Error("Failed to create user")
+		slog.Error("Failed to create user", "error", err)
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
@@ -61,9 +66,9 @@ func (r *UserRepository) Create(user *models.User) (*models.User, error) {
 
 func (r *UserRepository) GetByEmail(email string) (*models.User, error) {
 	query := `
-		SELECT id, email, password_hash, first_name, last_name, is_active, 
+		SELECT id, email, COALESCE(password_hash, ''), first_name, last_name, role, login_type, is_active,
 		       last_login_at, created_at, updated_at
-		FROM users 
+		FROM users
 		WHERE email = $1`
 
 	user := &models.User{}
@@ -73,6 +78,8 @@ func (r *UserRepository) GetByEmail(email string) (*models.User, error) {
 		&user.PasswordHash,
 		&user.FirstName,
 		&user.LastName,
+		&user.Role,
+		&user.LoginType,
 		&user.IsActive,
 		&user.LastLoginAt,
 		&user.CreatedAt,
@@ -83,7 +90,7 @@ func (r *UserRepository) GetByEmail(email string) (*models.User, error) {
 		if err == sql.ErrNoRows {
 			return nil, errors.New("user not found")
 		}
-		logrus.WithError(err).WithField("email", email).Error("Failed to get user by email")
+		slog.Error("Failed to get user by email", "error", err, "email", email)
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
@@ -96,9 +103,9 @@ func (r *UserRepository) GetByEmail(email string) (*models.User, error) {
 
 func (r *UserRepository) GetByID(id uuid.UUID) (*models.User, error) {
 	query := `
-		SELECT id, email, password_hash, first_name, last_name, is_active,
+		SELECT id, email, COALESCE(password_hash, ''), first_name, last_name, role, login_type, is_active,
 		       last_login_at, created_at, updated_at
-		FROM users 
+		FROM users
 		WHERE id = $1`
 
 	user := &models.User{}
@@ -108,6 +115,8 @@ func (r *UserRepository) GetByID(id uuid.UUID) (*models.User, error) {
 		&user.PasswordHash,
 		&user.FirstName,
 		&user.LastName,
+		&user.Role,
+		&user.LoginType,
 		&user.IsActive,
 		&user.LastLoginAt,
 		&user.CreatedAt,
@@ -118,7 +127,7 @@ func (r *UserRepository) GetByID(id uuid.UUID) (*models.User, error) {
 		if err == sql.ErrNoRows {
 			return nil, errors.New("user not found")
 		}
-		logrus.WithError(err).WithField("user_id", id).Error("Failed to get user by ID")
+		slog.Error("Failed to get user by ID", "error", err, "user_id", id)
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
@@ -135,7 +144,7 @@ func (r *UserRepository) UpdateLastLogin(id uuid.UUID) error {
 	now := time.Now().UTC()
 	_, err := r.db.Exec(query, now, now, id)
 	if err != nil {
-		logrus.WithError(err).WithField("user_id", id).Error("Failed to update last login")
+		slog.Error("Failed to update last login", "error", err, "user_id", id)
 		return fmt.Errorf("failed to update last login: %w", err)
 	}
 
@@ -148,7 +157,7 @@ func (r *UserRepository) UpdatePassword(id uuid.UUID, passwordHash string) error
 	now := time.Now().UTC()
 	result, err := r.db.Exec(query, passwordHash, now, id)
 	if err != nil {
-		logrus.WithError(err).WithField("user_id", id).Error("Failed to update password")
+		slog.Error("Failed to update password", "error", err, "user_id", id)
 		return fmt.Errorf("failed to update password: %w", err)
 	}
 
@@ -171,7 +180,7 @@ func (r *UserRepository) Delete(id uuid.UUID) error {
 	now := time.Now().UTC()
 	result, err := r.db.Exec(query, now, id)
 	if err != nil {
-		logrus.WithError(err).WithField("user_id", id).Error("Failed to delete user")
+		slog.Error("Failed to delete user", "error", err, "user_id", id)
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
 
@@ -193,13 +202,139 @@ func (r *UserRepository) GetActiveUserCount() (int64, error) {
 	var count int64
 	err := r.db.QueryRow(query).Scan(&count)
 	if err != nil {
-		logrus.WithError(err).Error("Failed to get active user count")
+		slog.Error("Failed to get active user count", "error", err)
 		return 0, fmt.Errorf("failed to get user count: %w", err)
 	}
 
 	return count, nil
 }
 
+// ListUsers returns a page of users matching filter, ordered newest first,
+// along with the total number of matching rows for pagination.
+func (r *UserRepository) ListUsers(filter models.UserListFilter, page, pageSize int) (*models.UserListResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	conditions := []string{"1=1"}
+	args := []interface{}{}
+
+	if filter.Role != "" {
+		args = append(args, filter.Role)
+		conditions = append(conditions, fmt.Sprintf("role = $%d", len(args)))
+	}
+	if filter.IsActive != nil {
+		args = append(args, *filter.IsActive)
+		conditions = append(conditions, fmt.Sprintf("is_active = $%d", len(args)))
+	}
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		conditions = append(conditions, fmt.Sprintf("email ILIKE $%d", len(args)))
+	}
+	whereClause := strings.Join(conditions, " AND ")
+
+	var totalCount int64
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM users WHERE %s", whereClause)
+	if err := r.db.QueryRow(countQuery, args...).Scan(&totalCount); err != nil {
+		slog.Error("Failed to count users", "error", err)
+		return nil, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	args = append(args, pageSize, (page-1)*pageSize)
+	query := fmt.Sprintf(`
+		SELECT id, email, COALESCE(password_hash, ''), first_name, last_name, role, login_type, is_active,
+		       last_login_at, created_at, updated_at
+		FROM users
+		WHERE %s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d`, whereClause, len(args)-1, len(args))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		slog.Error("Failed to list users", "error", err)
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	defer rows.Close()
+
+	users := []*models.User{}
+	for rows.Next() {
+		user := &models.User{}
+		if err := rows.Scan(
+			&user.ID,
+			&user.Email,
+			&user.PasswordHash,
+			&user.FirstName,
+			&user.LastName,
+			&user.Role,
+			&user.LoginType,
+			&user.IsActive,
+			&user.LastLoginAt,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		); err != nil {
+			slog.Error("Failed to scan user", "error", err)
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		user.PasswordHash = ""
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read user rows: %w", err)
+	}
+
+	return &models.UserListResponse{
+		Users:      users,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalCount: totalCount,
+	}, nil
+}
+
+// UpdateRole changes a user's role, e.g. promoting them to admin.
+func (r *UserRepository) UpdateRole(id uuid.UUID, role string) error {
+	query := `UPDATE users SET role = $1, updated_at = $2 WHERE id = $3`
+
+	result, err := r.db.Exec(query, role, time.Now().UTC(), id)
+	if err != nil {
+		slog.Error("Failed to update role", "error", err, "user_id", id)
+		return fmt.Errorf("failed to update role: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
+
+// SetActive activates or deactivates a user's account.
+func (r *UserRepository) SetActive(id uuid.UUID, active bool) error {
+	query := `UPDATE users SET is_active = $1, updated_at = $2 WHERE id = $3`
+
+	result, err := r.db.Exec(query, active, time.Now().UTC(), id)
+	if err != nil {
+		slog.Error("Failed to update active status", "error", err, "user_id", id)
+		return fmt.Errorf("failed to update active status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
+
 func (r *UserRepository) GetRecentRegistrations(days int) (int64, error) {
 	query := `SELECT COUNT(*) FROM users WHERE created_at >= $1 AND is_active = true`
 	
@@ -207,7 +342,7 @@ func (r *UserRepository) GetRecentRegistrations(days int) (int64, error) {
 	var count int64
 	err := r.db.QueryRow(query, since).Scan(&count)
 	if err != nil {
-		logrus.WithError(err).Error("Failed to get recent registrations")
+		slog.Error("Failed to get recent registrations", "error", err)
 		return 0, fmt.Errorf("failed to get recent registrations: %w", err)
 	}
 
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"auth-service/internal/models"
+)
+
+type UserLinkRepository struct {
+	db *sql.DB
+}
+
+func NewUserLinkRepository(db *sql.DB) *UserLinkRepository {
+	return &UserLinkRepository{
+		db: db,
+	}
+}
+
+func (r *UserLinkRepository) Create(link *models.UserLink) (*models.UserLink, error) {
+	query := `
+		INSERT INTO user_links (user_id, provider, provider_user_id, access_token_enc, refresh_token_enc, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at, updated_at`
+
+	now := time.Now().UTC()
+	err := r.db.QueryRow(
+		query,
+		link.UserID,
+		link.Provider,
+		link.ProviderUserID,
+		link.AccessTokenEnc,
+		link.RefreshTokenEnc,
+		link.ExpiresAt,
+		now,
+		now,
+	).Scan(&link.ID, &link.CreatedAt, &link.UpdatedAt)
+
+	if err != nil {
+		slog.Error("Failed to create user link", "error", err, "provider", link.Provider)
+		return nil, fmt.Errorf("failed to create user link: %w", err)
+	}
+
+	return link, nil
+}
+
+func (r *UserLinkRepository) GetByProvider(provider, providerUserID string) (*models.UserLink, error) {
+	query := `
+		SELECT id, user_id, provider, provider_user_id, access_token_enc, refresh_token_enc, expires_at, created_at, updated_at
+		FROM user_links
+		WHERE provider = $1 AND provider_user_id = $2`
+
+	link := &models.UserLink{}
+	err := r.db.QueryRow(query, provider, providerUserID).Scan(
+		&link.ID,
+		&link.UserID,
+		&link.Provider,
+		&link.ProviderUserID,
+		&link.AccessTokenEnc,
+		&link.RefreshTokenEnc,
+		&link.ExpiresAt,
+		&link.CreatedAt,
+		&link.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("user link not found")
+		}
+		slog.Error("Failed to get user link", "error", err, "provider", provider)
+		return nil, fmt.Errorf("failed to get user link: %w", err)
+	}
+
+	return link, nil
+}
+
+func (r *UserLinkRepository) GetAllForUser(userID uuid.UUID) ([]*models.UserLink, error) {
+	query := `
+		SELECT id, user_id, provider, provider_user_id, access_token_enc, refresh_token_enc, expires_at, created_at, updated_at
+		FROM user_links
+		WHERE user_id = $1`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user links: %w", err)
+	}
+	defer rows.Close()
+
+	var links []*models.UserLink
+	for rows.Next() {
+		link := &models.UserLink{}
+		if err := rows.Scan(
+			&link.ID,
+			&link.UserID,
+			&link.Provider,
+			&link.ProviderUserID,
+			&link.AccessTokenEnc,
+			&link.RefreshTokenEnc,
+			&link.ExpiresAt,
+			&link.CreatedAt,
+			&link.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan user link: %w", err)
+		}
+		links = append(links, link)
+	}
+
+	return links, rows.Err()
+}
+
+func (r *UserLinkRepository) UpdateTokens(id uuid.UUID, accessTokenEnc, refreshTokenEnc *string, expiresAt *time.Time) error {
+	query := `
+		UPDATE user_links
+		SET access_token_enc = $1, refresh_token_enc = $2, expires_at = $3, updated_at = $4
+		WHERE id = $5`
+
+	_, err := r.db.Exec(query, accessTokenEnc, refreshTokenEnc, expiresAt, time.Now().UTC(), id)
+	if err != nil {
+		return fmt.Errorf("failed to update user link tokens: %w", err)
+	}
+
+	return nil
+}
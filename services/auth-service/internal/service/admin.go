@@ -0,0 +1,54 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+
+	"auth-service/internal/models"
+	"auth-service/internal/rbac"
+)
+
+// ListUsers returns a page of user accounts for the admin console.
+func (s *AuthService) ListUsers(filter models.UserListFilter, page, pageSize int) (*models.UserListResponse, error) {
+	return s.userRepo.ListUsers(filter, page, pageSize)
+}
+
+// UpdateUserRole changes a user's role, e.g. promoting them to admin. The
+// caller must outrank the role being granted, so an admin can hand out only
+// the "user" role and a superadmin only "user" or "admin"; no caller can
+// grant "superadmin" through this method. This stops an admin promoting any
+// account, including their own, to admin or superadmin. Only the role being
+// granted is checked, not the target user's current role.
+func (s *AuthService) UpdateUserRole(callerRole rbac.Role, userID uuid.UUID, role string) error {
+	target := rbac.Role(role)
+	if !target.Valid() {
+		return errors.New("invalid role")
+	}
+	if !callerRole.Outranks(target) {
+		return errors.New("insufficient privilege to grant this role")
+	}
+
+	return s.userRepo.UpdateRole(userID, role)
+}
+
+// ActivateUser re-enables a previously deactivated account.
+func (s *AuthService) ActivateUser(userID uuid.UUID) error {
+	return s.userRepo.SetActive(userID, true)
+}
+
+// DeactivateUser disables an account and revokes all of its active
+// sessions so the deactivation takes effect immediately.
+func (s *AuthService) DeactivateUser(userID uuid.UUID) error {
+	if err := s.userRepo.SetActive(userID, false); err != nil {
+		return err
+	}
+
+	return s.RevokeAllSessions(userID)
+}
+
+// ForceLogout revokes every refresh token issued to a user, signing them
+// out everywhere without otherwise changing the account.
+func (s *AuthService) ForceLogout(userID uuid.UUID) error {
+	return s.RevokeAllSessions(userID)
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"auth-service/internal/rbac"
+)
+
+// TestUpdateUserRole_RejectsEscalation covers the bug fixed in this series:
+// an admin could otherwise grant "admin" or "superadmin" to any account,
+// including their own. Every case here is expected to be rejected before
+// ever reaching the user repository, so a zero-value AuthService is enough.
+func TestUpdateUserRole_RejectsEscalation(t *testing.T) {
+	tests := []struct {
+		name       string
+		callerRole rbac.Role
+		grantRole  string
+	}{
+		{"admin cannot self-promote to superadmin", rbac.RoleAdmin, "superadmin"},
+		{"admin cannot grant admin", rbac.RoleAdmin, "admin"},
+		{"user cannot grant user", rbac.RoleUser, "user"},
+		{"unknown caller role grants nothing", rbac.Role("bogus"), "user"},
+		{"unknown role requested is rejected", rbac.RoleSuperAdmin, "root"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &AuthService{}
+
+			if err := s.UpdateUserRole(tt.callerRole, uuid.New(), tt.grantRole); err == nil {
+				t.Fatalf("UpdateUserRole(%s -> %s): expected error, got nil", tt.callerRole, tt.grantRole)
+			}
+		})
+	}
+}
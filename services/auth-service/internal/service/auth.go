@@ -1,37 +1,105 @@
 package service
 
 import (
+	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
+	libwebauthn "github.com/go-webauthn/webauthn/webauthn"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
-	"github.com/sirupsen/logrus"
+	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/oauth2"
 
+	"auth-service/internal/blacklist"
+	"auth-service/internal/cryptoutil"
+	"auth-service/internal/jwtkeys"
+	"auth-service/internal/mailer"
 	"auth-service/internal/models"
+	"auth-service/internal/oauth"
+	"auth-service/internal/ratelimit"
 	"auth-service/internal/repository"
+	"auth-service/internal/totpreplay"
+	webauthnutil "auth-service/internal/webauthn"
 )
 
+const (
+	forgotPasswordLimitPerUser = 3
+	forgotPasswordLimitPerIP   = 10
+	forgotPasswordLimitWindow  = time.Hour
+)
+
+// ErrWebAuthnRequired is returned by Login when the account has a
+// registered passkey: password auth is not accepted for it, and the caller
+// must start a WebAuthn login ceremony (BeginLogin) instead.
+var ErrWebAuthnRequired = errors.New("webauthn login required")
+
 type AuthService struct {
-	userRepo     *repository.UserRepository
-	jwtSecret    []byte
-	tokenExpiry  time.Duration
-	refreshExpiry time.Duration
+	userRepo                *repository.UserRepository
+	loginProvider           LoginProvider
+	refreshTokenRepo        *repository.RefreshTokenRepository
+	passwordResetRepo       *repository.PasswordResetRepository
+	userLinkRepo            *repository.UserLinkRepository
+	mfaRepo                 *repository.MFARepository
+	credentialRepo          *repository.CredentialRepository
+	sessionRepo             *repository.SessionRepository
+	accountLockoutRepo      *repository.AccountLockoutRepository
+	tokenBlacklist          blacklist.TokenBlacklist
+	mailer                  mailer.Mailer
+	rateLimiter             ratelimit.Limiter
+	oauthRegistry           *oauth.Registry
+	jwtKeys                 *jwtkeys.Manager
+	tokenOperations         *prometheus.CounterVec
+	webAuthn                *libwebauthn.WebAuthn
+	webauthnChallenges      webauthnutil.ChallengeStore
+	totpReplayGuard         totpreplay.Guard
+	issuer                  string
+	tokenExpiry             time.Duration
+	refreshExpiry           time.Duration
+	passwordResetTTL        time.Duration
+	appBaseURL              string
+	oauthTokenEncryptionKey []byte
+	mfaEncryptionKey        []byte
+	lockoutThreshold        int
+	lockoutBaseWindow       time.Duration
 }
 
-func NewAuthService(userRepo *repository.UserRepository) *AuthService {
-	jwtSecret := []byte(os.Getenv("JWT_SECRET"))
-	if len(jwtSecret) == 0 {
-		jwtSecret = []byte("default-secret-change-in-production")
-		logrus.Warning("JWT_SECRET not set, using default secret")
-	}
-
+func NewAuthService(
+	userRepo *repository.UserRepository,
+	loginProvider LoginProvider,
+	refreshTokenRepo *repository.RefreshTokenRepository,
+	passwordResetRepo *repository.PasswordResetRepository,
+	userLinkRepo *repository.UserLinkRepository,
+	mfaRepo *repository.MFARepository,
+	credentialRepo *repository.CredentialRepository,
+	sessionRepo *repository.SessionRepository,
+	accountLockoutRepo *repository.AccountLockoutRepository,
+	tokenBlacklist blacklist.TokenBlacklist,
+	mailSender mailer.Mailer,
+	rateLimiter ratelimit.Limiter,
+	oauthRegistry *oauth.Registry,
+	jwtKeys *jwtkeys.Manager,
+	tokenOperations *prometheus.CounterVec,
+	webAuthn *libwebauthn.WebAuthn,
+	webauthnChallenges webauthnutil.ChallengeStore,
+	totpReplayGuard totpreplay.Guard,
+	issuer string,
+	passwordResetTTL time.Duration,
+	appBaseURL string,
+	oauthTokenEncryptionKey string,
+	mfaEncryptionKey string,
+	lockoutThreshold int,
+	lockoutBaseWindow time.Duration,
+) *AuthService {
 	tokenExpiry := 24 * time.Hour // default 24 hours
 	if expiryStr := os.Getenv("JWT_EXPIRY_HOURS"); expiryStr != "" {
 		if hours, err := strconv.Atoi(expiryStr); err == nil {
@@ -47,13 +115,43 @@ func NewAuthService(userRepo *repository.UserRepository) *AuthService {
 	}
 
 	return &AuthService{
-		userRepo:      userRepo,
-		jwtSecret:     jwtSecret,
-		tokenExpiry:   tokenExpiry,
-		refreshExpiry: refreshExpiry,
+		userRepo:                userRepo,
+		loginProvider:           loginProvider,
+		refreshTokenRepo:        refreshTokenRepo,
+		passwordResetRepo:       passwordResetRepo,
+		userLinkRepo:            userLinkRepo,
+		mfaRepo:                 mfaRepo,
+		credentialRepo:          credentialRepo,
+		sessionRepo:             sessionRepo,
+		accountLockoutRepo:      accountLockoutRepo,
+		tokenBlacklist:          tokenBlacklist,
+		mailer:                  mailSender,
+		rateLimiter:             rateLimiter,
+		oauthRegistry:           oauthRegistry,
+		jwtKeys:                 jwtKeys,
+		tokenOperations:         tokenOperations,
+		webAuthn:                webAuthn,
+		webauthnChallenges:      webauthnChallenges,
+		totpReplayGuard:         totpReplayGuard,
+		issuer:                  issuer,
+		tokenExpiry:             tokenExpiry,
+		refreshExpiry:           refreshExpiry,
+		passwordResetTTL:        passwordResetTTL,
+		appBaseURL:              appBaseURL,
+		oauthTokenEncryptionKey: []byte(oauthTokenEncryptionKey),
+		mfaEncryptionKey:        []byte(mfaEncryptionKey),
+		lockoutThreshold:        lockoutThreshold,
+		lockoutBaseWindow:       lockoutBaseWindow,
 	}
 }
 
+// hashToken returns the hex-encoded SHA-256 digest of a token, which is what
+// gets persisted so a database leak doesn't yield a directly usable token.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
 func (s *AuthService) Register(req *models.RegisterRequest) (*models.User, error) {
 	// Check if user already exists
 	existingUser, _ := s.userRepo.GetByEmail(req.Email)
@@ -64,7 +162,7 @@ func (s *AuthService) Register(req *models.RegisterRequest) (*models.User, error
 	// Hash password
 	passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
-		logrus.WithError(err).Error("Failed to hash password")
+		slog.Error("Failed to hash password", "error", err)
 		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
 
@@ -87,62 +185,133 @@ func (s *AuthService) Register(req *models.RegisterRequest) (*models.User, error
 	return createdUser, nil
 }
 
+// Login authenticates a user through the configured LoginProvider, which is
+// bcrypt-against-Postgres by default. Repeated failures lock the account
+// out for an exponentially increasing window; a successful login clears
+// the failure count.
 func (s *AuthService) Login(req *models.LoginRequest) (*models.User, error) {
-	// Get user by email
-	user, err := s.userRepo.GetByEmail(req.Email)
+	locked, until, err := s.accountLockoutRepo.IsLocked(req.Email)
 	if err != nil {
-		return nil, errors.New("invalid credentials")
+		return nil, fmt.Errorf("failed to check account lockout: %w", err)
+	}
+	if locked {
+		return nil, fmt.Errorf("account is temporarily locked until %s", until.Format(time.RFC3339))
 	}
 
-	// Verify password
-	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password))
-	if err != nil {
-		return nil, errors.New("invalid credentials")
+	// An account with a registered passkey must complete login with it --
+	// a password alone is no longer sufficient, otherwise registering a
+	// passkey buys no real protection.
+	if user, lookupErr := s.userRepo.GetByEmail(req.Email); lookupErr == nil {
+		creds, credErr := s.credentialRepo.GetByUserID(user.ID)
+		if credErr == nil && len(creds) > 0 {
+			return nil, ErrWebAuthnRequired
+		}
 	}
 
-	// Clear sensitive data
-	user.PasswordHash = ""
+	if req.Password == "" {
+		s.recordLoginFailure(req.Email)
+		return nil, errors.New("password is required")
+	}
 
+	user, err := s.loginProvider.AttemptLogin(req.Email, req.Password)
+	if err != nil {
+		s.recordLoginFailure(req.Email)
+		return nil, err
+	}
+
+	s.resetLoginFailures(req.Email)
 	return user, nil
 }
 
-func (s *AuthService) GenerateTokens(user *models.User) (*models.AuthResponse, error) {
+// recordLoginFailure increments identifier's consecutive-failure count and
+// logs a security audit event if that failure just triggered a new lockout.
+func (s *AuthService) recordLoginFailure(identifier string) {
+	lockout, err := s.accountLockoutRepo.RecordFailure(identifier, s.lockoutThreshold, s.lockoutBaseWindow)
+	if err != nil {
+		slog.Warn("Failed to record login failure", "error", err, "identifier", identifier)
+		return
+	}
+	if lockout.LockedUntil != nil {
+		slog.Warn("security_audit: account locked after repeated failed logins",
+			"event", "account_locked", "identifier", identifier, "failure_count", lockout.FailureCount, "locked_until", lockout.LockedUntil)
+	}
+}
+
+// resetLoginFailures clears identifier's failure count after a successful
+// login or password reset.
+func (s *AuthService) resetLoginFailures(identifier string) {
+	if err := s.accountLockoutRepo.Reset(identifier); err != nil {
+		slog.Warn("Failed to reset account lockout", "error", err, "identifier", identifier)
+	}
+}
+
+// GenerateTokens starts a brand new session for user and mints the first
+// access/refresh token pair for it. userAgent and clientIP are stored on the
+// session purely for the "my sessions" listing; pass "" for either when
+// unavailable (e.g. server-initiated token issuance).
+func (s *AuthService) GenerateTokens(user *models.User, userAgent, clientIP string) (*models.AuthResponse, error) {
+	session, err := s.sessionRepo.Create(user.ID, userAgent, clientIP)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return s.issueTokenPair(user, session.ID)
+}
+
+// issueTokenPair signs a fresh access/refresh token pair bound to sid, an
+// existing session. Used both for the initial login (a brand new session)
+// and for refresh-token rotation (the same session carried forward).
+func (s *AuthService) issueTokenPair(user *models.User, sid uuid.UUID) (*models.AuthResponse, error) {
 	now := time.Now()
 	expiresAt := now.Add(s.tokenExpiry)
+	jti := uuid.New().String()
 
 	// Create access token
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+	token := jwt.NewWithClaims(s.jwtKeys.SigningMethod(), jwt.MapClaims{
 		"sub":       user.ID,
 		"email":     user.Email,
 		"exp":       expiresAt.Unix(),
 		"iat":       now.Unix(),
 		"type":      "access",
 		"user_id":   user.ID,
+		"role":      user.Role,
 		"is_active": user.IsActive,
+		"jti":       jti,
+		"sid":       sid,
 	})
+	token.Header["kid"] = s.jwtKeys.CurrentKID()
 
-	accessToken, err := token.SignedString(s.jwtSecret)
+	accessToken, err := token.SignedString(s.jwtKeys.SigningKey())
 	if err != nil {
-		logrus.WithError(err).Error("Failed to sign access token")
+		s.tokenOperations.WithLabelValues(signOperation(s.jwtKeys), "failure").Inc()
+		slog.Error("Failed to sign access token", "error", err)
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
+	s.tokenOperations.WithLabelValues(signOperation(s.jwtKeys), "success").Inc()
 
 	// Create refresh token
 	refreshExpiresAt := now.Add(s.refreshExpiry)
-	refreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+	refreshToken := jwt.NewWithClaims(s.jwtKeys.SigningMethod(), jwt.MapClaims{
 		"sub":     user.ID,
 		"exp":     refreshExpiresAt.Unix(),
 		"iat":     now.Unix(),
 		"type":    "refresh",
 		"user_id": user.ID,
+		"sid":     sid,
 	})
+	refreshToken.Header["kid"] = s.jwtKeys.CurrentKID()
 
-	refreshTokenString, err := refreshToken.SignedString(s.jwtSecret)
+	refreshTokenString, err := refreshToken.SignedString(s.jwtKeys.SigningKey())
 	if err != nil {
-		logrus.WithError(err).Error("Failed to sign refresh token")
+		slog.Error("Failed to sign refresh token", "error", err)
 		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
 	}
 
+	if _, err := s.refreshTokenRepo.Create(user.ID, hashToken(refreshTokenString), refreshExpiresAt); err != nil {
+		slog.Error("Failed to persist refresh token", "error", err)
+		return nil, fmt.Errorf("failed to persist refresh token: %w", err)
+	}
+
 	return &models.AuthResponse{
 		Token:        accessToken,
 		RefreshToken: refreshTokenString,
@@ -153,27 +322,50 @@ func (s *AuthService) GenerateTokens(user *models.User) (*models.AuthResponse, e
 }
 
 func (s *AuthService) VerifyToken(tokenString string) (*models.User, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return s.jwtSecret, nil
-	})
+	token, err := jwt.Parse(tokenString, s.jwtKeys.Keyfunc)
 
 	if err != nil {
+		s.tokenOperations.WithLabelValues(verifyOperation(s.jwtKeys), "failure").Inc()
 		return nil, fmt.Errorf("invalid token: %w", err)
 	}
 
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok || !token.Valid {
+		s.tokenOperations.WithLabelValues(verifyOperation(s.jwtKeys), "failure").Inc()
 		return nil, errors.New("invalid token claims")
 	}
+	s.tokenOperations.WithLabelValues(verifyOperation(s.jwtKeys), "success").Inc()
 
 	tokenType, ok := claims["type"].(string)
 	if !ok || tokenType != "access" {
 		return nil, errors.New("invalid token type")
 	}
 
+	jti, _ := claims["jti"].(string)
+	if jti != "" {
+		blacklisted, err := s.tokenBlacklist.IsBlacklisted(jti)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check token blacklist: %w", err)
+		}
+		if blacklisted {
+			return nil, errors.New("token has been revoked")
+		}
+	}
+
+	if sidString, ok := claims["sid"].(string); ok && sidString != "" {
+		sid, err := uuid.Parse(sidString)
+		if err != nil {
+			return nil, fmt.Errorf("invalid session ID format: %w", err)
+		}
+		revoked, err := s.sessionRepo.IsRevoked(sid)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check session: %w", err)
+		}
+		if revoked {
+			return nil, errors.New("session has been revoked")
+		}
+	}
+
 	userIDString, ok := claims["user_id"].(string)
 	if !ok {
 		return nil, errors.New("invalid user ID in token")
@@ -195,13 +387,32 @@ func (s *AuthService) VerifyToken(tokenString string) (*models.User, error) {
 	return user, nil
 }
 
+// parseAccessTokenClaims validates an access token's signature and type
+// without consulting the blacklist or rejecting an expired token, so Logout
+// can still read the jti/sid of a token that's already past its exp (the
+// common case of a client calling logout in response to a 401) in order to
+// blacklist and revoke it.
+func (s *AuthService) parseAccessTokenClaims(tokenString string) (jwt.MapClaims, error) {
+	parser := jwt.NewParser(jwt.WithoutClaimsValidation())
+	token, err := parser.Parse(tokenString, s.jwtKeys.Keyfunc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token claims")
+	}
+
+	if tokenType, ok := claims["type"].(string); !ok || tokenType != "access" {
+		return nil, errors.New("invalid token type")
+	}
+
+	return claims, nil
+}
+
 func (s *AuthService) RefreshToken(refreshTokenString string) (*models.AuthResponse, error) {
-	token, err := jwt.Parse(refreshTokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return s.jwtSecret, nil
-	})
+	token, err := jwt.Parse(refreshTokenString, s.jwtKeys.Keyfunc)
 
 	if err != nil {
 		return nil, fmt.Errorf("invalid refresh token: %w", err)
@@ -226,66 +437,376 @@ func (s *AuthService) RefreshToken(refreshTokenString string) (*models.AuthRespo
 		return nil, fmt.Errorf("invalid user ID format: %w", err)
 	}
 
+	// Look up the server-side record so a revoked, expired, or unknown
+	// refresh token can never mint a new token pair.
+	stored, err := s.refreshTokenRepo.GetByTokenHash(hashToken(refreshTokenString))
+	if err != nil {
+		return nil, fmt.Errorf("refresh token unknown: %w", err)
+	}
+	if stored.IsRevoked {
+		return nil, errors.New("refresh token has been revoked")
+	}
+	if stored.ExpiresAt.Before(time.Now().UTC()) {
+		return nil, errors.New("refresh token has expired")
+	}
+
+	sidString, ok := claims["sid"].(string)
+	if !ok || sidString == "" {
+		return nil, errors.New("refresh token missing session ID")
+	}
+	sid, err := uuid.Parse(sidString)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session ID format: %w", err)
+	}
+	if revoked, err := s.sessionRepo.IsRevoked(sid); err != nil {
+		return nil, fmt.Errorf("failed to check session: %w", err)
+	} else if revoked {
+		return nil, errors.New("session has been revoked")
+	}
+
 	// Get user from database
 	user, err := s.userRepo.GetByID(userID)
 	if err != nil {
 		return nil, fmt.Errorf("user not found: %w", err)
 	}
 
-	// Generate new tokens
-	return s.GenerateTokens(user)
+	// Rotate: the presented token is single-use, so revoke it before
+	// issuing the replacement pair.
+	if err := s.refreshTokenRepo.Revoke(stored.ID); err != nil {
+		return nil, fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+
+	if err := s.sessionRepo.Touch(sid); err != nil {
+		slog.Warn("Failed to touch session on refresh", "error", err)
+	}
+
+	return s.issueTokenPair(user, sid)
+}
+
+// OAuthProvider looks up a configured social/OIDC provider by name, for
+// handlers building the redirect to start the ceremony.
+func (s *AuthService) OAuthProvider(provider string) (oauth.OIDCProvider, bool) {
+	return s.oauthRegistry.Get(provider)
+}
+
+// OAuthLogin completes a social login callback: it exchanges the code for
+// the provider's identity, then either signs in an already-linked user,
+// links the provider to an existing password account with the same
+// verified email, or JIT-provisions a brand new OIDC user. codeVerifier is
+// the PKCE verifier matching the challenge sent to the provider's authorize
+// endpoint; it's optional and ignored by providers that don't need it.
+func (s *AuthService) OAuthLogin(ctx context.Context, provider, code, codeVerifier string) (*models.User, error) {
+	p, ok := s.oauthRegistry.Get(provider)
+	if !ok {
+		return nil, fmt.Errorf("unknown oauth provider: %s", provider)
+	}
+
+	var exchangeOpts []oauth2.AuthCodeOption
+	if codeVerifier != "" {
+		exchangeOpts = append(exchangeOpts, oauth2.VerifierOption(codeVerifier))
+	}
+
+	info, err := p.Exchange(ctx, code, exchangeOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("oauth exchange failed: %w", err)
+	}
+
+	if link, err := s.userLinkRepo.GetByProvider(provider, info.ProviderUserID); err == nil {
+		user, err := s.userRepo.GetByID(link.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("linked user not found: %w", err)
+		}
+
+		s.updateLinkTokens(link.ID, info)
+		user.PasswordHash = ""
+		return user, nil
+	}
+
+	if !info.EmailVerified {
+		return nil, errors.New("oauth provider did not return a verified email")
+	}
+
+	// No existing link; see if a user already owns this email (password
+	// signup first, social login second) and attach the link to it.
+	user, err := s.userRepo.GetByEmail(info.Email)
+	if err != nil {
+		user, err = s.userRepo.Create(&models.User{
+			Email:     info.Email,
+			LoginType: models.LoginTypeOIDC,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to provision oauth user: %w", err)
+		}
+	}
+
+	link := &models.UserLink{
+		UserID:         user.ID,
+		Provider:       provider,
+		ProviderUserID: info.ProviderUserID,
+	}
+	s.setLinkTokens(link, info)
+
+	if _, err := s.userLinkRepo.Create(link); err != nil {
+		return nil, fmt.Errorf("failed to link oauth account: %w", err)
+	}
+
+	user.PasswordHash = ""
+	return user, nil
+}
+
+func (s *AuthService) setLinkTokens(link *models.UserLink, info *oauth.UserInfo) {
+	if len(s.oauthTokenEncryptionKey) == 0 {
+		return
+	}
+
+	if info.AccessToken != "" {
+		if enc, err := cryptoutil.Encrypt(s.oauthTokenEncryptionKey, info.AccessToken); err == nil {
+			link.AccessTokenEnc = &enc
+		}
+	}
+	if info.RefreshToken != "" {
+		if enc, err := cryptoutil.Encrypt(s.oauthTokenEncryptionKey, info.RefreshToken); err == nil {
+			link.RefreshTokenEnc = &enc
+		}
+	}
+}
+
+func (s *AuthService) updateLinkTokens(linkID uuid.UUID, info *oauth.UserInfo) {
+	link := &models.UserLink{}
+	s.setLinkTokens(link, info)
+
+	if err := s.userLinkRepo.UpdateTokens(linkID, link.AccessTokenEnc, link.RefreshTokenEnc, nil); err != nil {
+		slog.Warn("Failed to update oauth link tokens", "error", err)
+	}
 }
 
 func (s *AuthService) UpdateLastLogin(userID uuid.UUID) error {
 	return s.userRepo.UpdateLastLogin(userID)
 }
 
-func (s *AuthService) Logout(tokenString string) error {
-	// In a production system, you might want to add the token to a blacklist
-	// For now, we'll just validate the token
-	_, err := s.VerifyToken(tokenString)
-	return err
+// Logout revokes the refresh token presented by the caller so it can no
+// longer be used to mint new access tokens, and blacklists the access
+// token's jti so it's rejected by VerifyToken for the remainder of its
+// natural lifetime.
+func (s *AuthService) Logout(accessToken, refreshToken string) error {
+	claims, err := s.parseAccessTokenClaims(accessToken)
+	if err != nil {
+		return err
+	}
+
+	if jti, ok := claims["jti"].(string); ok && jti != "" {
+		var expiresAt time.Time
+		if expFloat, ok := claims["exp"].(float64); ok {
+			expiresAt = time.Unix(int64(expFloat), 0)
+		}
+
+		// An already-expired token needs no blacklist entry: VerifyToken's
+		// own exp check already rejects it.
+		if ttl := time.Until(expiresAt); ttl > 0 {
+			if err := s.tokenBlacklist.Add(jti, ttl); err != nil {
+				slog.Warn("Failed to blacklist access token", "error", err)
+			}
+		}
+	}
+
+	if sidString, ok := claims["sid"].(string); ok && sidString != "" {
+		if sid, err := uuid.Parse(sidString); err == nil {
+			if err := s.sessionRepo.Revoke(sid); err != nil {
+				slog.Warn("Failed to revoke session on logout", "error", err)
+			}
+		}
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+
+	stored, err := s.refreshTokenRepo.GetByTokenHash(hashToken(refreshToken))
+	if err != nil {
+		// Already gone or never existed; nothing left to revoke.
+		return nil
+	}
+
+	return s.refreshTokenRepo.Revoke(stored.ID)
+}
+
+// ListSessions returns a user's active (non-revoked) sessions, most
+// recently used first, for the "my sessions" endpoint.
+func (s *AuthService) ListSessions(userID uuid.UUID) ([]*models.Session, error) {
+	return s.sessionRepo.ListActiveByUser(userID)
+}
+
+// RevokeSession revokes a single session by id, e.g. signing a specific
+// device out remotely. Ownership is checked so a user can't revoke another
+// user's session by guessing its id.
+func (s *AuthService) RevokeSession(userID, sid uuid.UUID) error {
+	session, err := s.sessionRepo.GetByID(sid)
+	if err != nil {
+		s.tokenOperations.WithLabelValues("revoke", "failure").Inc()
+		return err
+	}
+	if session.UserID != userID {
+		s.tokenOperations.WithLabelValues("revoke", "failure").Inc()
+		return errors.New("session not found")
+	}
+
+	if err := s.sessionRepo.Revoke(sid); err != nil {
+		s.tokenOperations.WithLabelValues("revoke", "failure").Inc()
+		return err
+	}
+	s.tokenOperations.WithLabelValues("revoke", "success").Inc()
+	return nil
+}
+
+// RevokeAllSessions revokes every session and refresh token issued to a
+// user, forcing re-authentication everywhere ("log out everywhere").
+// Intended for use on password changes, admin-forced logout, and other
+// security-sensitive events.
+func (s *AuthService) RevokeAllSessions(userID uuid.UUID) error {
+	if err := s.sessionRepo.RevokeAllForUser(userID); err != nil {
+		s.tokenOperations.WithLabelValues("revoke", "failure").Inc()
+		return err
+	}
+
+	if err := s.refreshTokenRepo.RevokeAllForUser(userID); err != nil {
+		s.tokenOperations.WithLabelValues("revoke", "failure").Inc()
+		return err
+	}
+
+	s.tokenOperations.WithLabelValues("revoke", "success").Inc()
+	return nil
+}
+
+// KeyRetentionWindow is the longest lifetime of any token this service
+// signs (access or refresh), i.e. how long a retired signing key must stay
+// in the JWKS for every outstanding token to still verify.
+func (s *AuthService) KeyRetentionWindow() time.Duration {
+	if s.refreshExpiry > s.tokenExpiry {
+		return s.refreshExpiry
+	}
+	return s.tokenExpiry
 }
 
-func (s *AuthService) ForgotPassword(email string) error {
+// PurgeExpiredRefreshTokens deletes refresh token rows past their expiry and
+// is intended to be called periodically from a background goroutine.
+func (s *AuthService) PurgeExpiredRefreshTokens() {
+	deleted, err := s.refreshTokenRepo.DeleteExpired()
+	if err != nil {
+		slog.Error("Failed to purge expired refresh tokens", "error", err)
+		return
+	}
+
+	if deleted > 0 {
+		slog.Info("Purged expired refresh tokens", "count", deleted)
+	}
+}
+
+// ForgotPassword issues a one-time reset token and emails it to the user,
+// rate-limited per email and per client IP to slow down enumeration and
+// mail-bombing attempts.
+func (s *AuthService) ForgotPassword(email, clientIP string) error {
+	if allowed, err := s.rateLimiter.Allow("forgot-password:email:"+email, forgotPasswordLimitPerUser, forgotPasswordLimitWindow); err != nil {
+		return fmt.Errorf("failed to check rate limit: %w", err)
+	} else if !allowed {
+		return errors.New("too many password reset requests, please try again later")
+	}
+
+	if allowed, err := s.rateLimiter.Allow("forgot-password:ip:"+clientIP, forgotPasswordLimitPerIP, forgotPasswordLimitWindow); err != nil {
+		return fmt.Errorf("failed to check rate limit: %w", err)
+	} else if !allowed {
+		return errors.New("too many password reset requests, please try again later")
+	}
+
 	// Check if user exists
 	user, err := s.userRepo.GetByEmail(email)
 	if err != nil {
 		// Don't reveal if user exists or not
-		logrus.WithField("email", email).Info("Password reset requested for unknown email")
+		slog.Info("Password reset requested for unknown email", "email", email)
 		return nil
 	}
 
-	// Generate reset token
+	if err := s.passwordResetRepo.DeleteExpiredForUser(user.ID); err != nil {
+		slog.Warn("Failed to clean up old password reset tokens", "error", err)
+	}
+
 	resetToken, err := s.generateResetToken()
 	if err != nil {
-		logrus.WithError(err).Error("Failed to generate reset token")
+		slog.Error("Failed to generate reset token", "error", err)
 		return fmt.Errorf("failed to generate reset token: %w", err)
 	}
 
-	// In a real application, you would:
-	// 1. Store the reset token in database with expiry
-	// 2. Send email to user with reset link
-	
-	logrus.WithFields(logrus.Fields{
-		"user_id":     user.ID,
-		"email":       email,
-		"reset_token": resetToken,
-	}).Info("Password reset token generated (demo mode)")
+	expiresAt := time.Now().UTC().Add(s.passwordResetTTL)
+	if _, err := s.passwordResetRepo.Create(user.ID, hashToken(resetToken), expiresAt); err != nil {
+		return fmt.Errorf("failed to store reset token: %w", err)
+	}
+
+	resetLink := fmt.Sprintf("%s/reset-password?token=%s", s.appBaseURL, resetToken)
+	subject, textBody, htmlBody, err := mailer.RenderPasswordResetEmail(resetLink, int(s.passwordResetTTL.Minutes()))
+	if err != nil {
+		return fmt.Errorf("failed to render reset email: %w", err)
+	}
+
+	if err := s.mailer.Send(email, subject, textBody, htmlBody); err != nil {
+		slog.Error("Failed to send password reset email", "error", err, "user_id", user.ID)
+		return fmt.Errorf("failed to send password reset email: %w", err)
+	}
 
 	return nil
 }
 
+// ResetPassword consumes a one-time reset token, sets a new password hash,
+// and revokes every outstanding session so a leaked token or credential
+// can't be used to stay logged in after the reset.
 func (s *AuthService) ResetPassword(resetToken, newPassword string) error {
-	// In a real application, you would:
-	// 1. Validate the reset token from database
-	// 2. Check if it's not expired
-	// 3. Get the associated user
-	// 4. Update their password
-	// 5. Invalidate the reset token
-
-	// For demo purposes, we'll just return an error
-	return errors.New("password reset not implemented in demo mode")
+	stored, err := s.passwordResetRepo.GetByTokenHash(hashToken(resetToken))
+	if err != nil {
+		return errors.New("invalid or expired reset token")
+	}
+
+	if stored.UsedAt != nil {
+		s.recordResetFailure(stored.UserID)
+		return errors.New("reset token has already been used")
+	}
+
+	if stored.ExpiresAt.Before(time.Now().UTC()) {
+		s.recordResetFailure(stored.UserID)
+		return errors.New("reset token has expired")
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		slog.Error("Failed to hash new password", "error", err)
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if err := s.userRepo.UpdatePassword(stored.UserID, string(passwordHash)); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	if err := s.passwordResetRepo.MarkUsed(stored.ID); err != nil {
+		slog.Warn("Failed to mark reset token used", "error", err)
+	}
+
+	if err := s.RevokeAllSessions(stored.UserID); err != nil {
+		slog.Warn("Failed to revoke sessions after password reset", "error", err)
+	}
+
+	if user, err := s.userRepo.GetByID(stored.UserID); err == nil {
+		s.resetLoginFailures(user.Email)
+	}
+
+	return nil
+}
+
+// recordResetFailure mirrors recordLoginFailure for a rejected reset-password
+// attempt (reused or expired token), keyed by the token owner's email so
+// lockouts apply across both the login and reset-password endpoints.
+func (s *AuthService) recordResetFailure(userID uuid.UUID) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return
+	}
+	s.recordLoginFailure(user.Email)
 }
 
 func (s *AuthService) generateResetToken() (string, error) {
@@ -294,4 +815,14 @@ func (s *AuthService) generateResetToken() (string, error) {
 		return "", err
 	}
 	return hex.EncodeToString(bytes), nil
+}
+
+// signOperation and verifyOperation name the tokenOperations metric label
+// for the manager's configured algorithm, e.g. "sign_rs256"/"verify_rs256".
+func signOperation(keys *jwtkeys.Manager) string {
+	return "sign_" + strings.ToLower(keys.SigningMethod().Alg())
+}
+
+func verifyOperation(keys *jwtkeys.Manager) string {
+	return "verify_" + strings.ToLower(keys.SigningMethod().Alg())
 }
\ No newline at end of file
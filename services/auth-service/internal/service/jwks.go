@@ -0,0 +1,28 @@
+package service
+
+import "auth-service/internal/jwtkeys"
+
+// OpenIDConfiguration is a minimal OIDC discovery document: just enough for
+// another service in the cluster to find our JWKS and know which signing
+// algorithm to expect.
+type OpenIDConfiguration struct {
+	Issuer                           string   `json:"issuer"`
+	JWKSURI                          string   `json:"jwks_uri"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+}
+
+// JWKS returns the public verification keys for every signing key this
+// service knows about, current and previously-rotated alike.
+func (s *AuthService) JWKS() jwtkeys.JWKS {
+	return s.jwtKeys.JWKS()
+}
+
+// OpenIDConfiguration returns the discovery document served at
+// /.well-known/openid-configuration.
+func (s *AuthService) OpenIDConfiguration() OpenIDConfiguration {
+	return OpenIDConfiguration{
+		Issuer:                           s.issuer,
+		JWKSURI:                          s.issuer + "/.well-known/jwks.json",
+		IDTokenSigningAlgValuesSupported: []string{s.jwtKeys.SigningMethod().Alg()},
+	}
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"auth-service/internal/models"
+	"auth-service/internal/repository"
+)
+
+// LoginProvider authenticates a username/password pair against a single
+// credential store. Factoring password auth behind this interface keeps
+// AuthService.Login from hardcoding bcrypt-against-Postgres as the only way
+// to authenticate, mirroring how oauth.OIDCProvider makes social login
+// pluggable.
+type LoginProvider interface {
+	AttemptLogin(email, password string) (*models.User, error)
+}
+
+// PasswordLoginProvider is the default LoginProvider: bcrypt password
+// verification against the users table.
+type PasswordLoginProvider struct {
+	userRepo *repository.UserRepository
+}
+
+func NewPasswordLoginProvider(userRepo *repository.UserRepository) *PasswordLoginProvider {
+	return &PasswordLoginProvider{userRepo: userRepo}
+}
+
+func (p *PasswordLoginProvider) AttemptLogin(email, password string) (*models.User, error) {
+	user, err := p.userRepo.GetByEmail(email)
+	if err != nil {
+		return nil, errors.New("invalid credentials")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, errors.New("invalid credentials")
+	}
+
+	user.PasswordHash = ""
+
+	return user, nil
+}
@@ -0,0 +1,291 @@
+package service
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image/png"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+	"golang.org/x/crypto/bcrypt"
+
+	"auth-service/internal/cryptoutil"
+	"auth-service/internal/models"
+)
+
+const (
+	mfaPendingTokenExpiry = 5 * time.Minute
+	mfaRecoveryCodeCount  = 10
+	mfaVerifyLimitPerUser = 10
+	mfaVerifyLimitWindow  = 15 * time.Minute
+
+	totpPeriod = 30 * time.Second
+	totpSkew   = 1
+	// totpReplayWindow covers every step a code could validate against
+	// (the current step plus totpSkew on either side), so a code can't be
+	// replayed anywhere within its real validity window.
+	totpReplayWindow = totpPeriod * (2*totpSkew + 1)
+)
+
+// NeedsMFA reports whether a user has a confirmed TOTP enrollment and must
+// complete a second factor before GenerateTokens is called.
+func (s *AuthService) NeedsMFA(userID uuid.UUID) (bool, error) {
+	mfa, err := s.mfaRepo.GetByUserID(userID)
+	if err != nil {
+		return false, nil
+	}
+
+	return mfa.ConfirmedAt != nil, nil
+}
+
+// EnrollMFA generates a new TOTP secret and recovery codes for a user. The
+// enrollment isn't active until ConfirmMFA validates the first code.
+func (s *AuthService) EnrollMFA(user *models.User) (*models.MFAEnrollResponse, error) {
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      "auth-service",
+		AccountName: user.Email,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate totp secret: %w", err)
+	}
+
+	secretEnc, err := cryptoutil.Encrypt(s.mfaEncryptionKey, key.Secret())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt totp secret: %w", err)
+	}
+
+	recoveryCodes := make([]string, mfaRecoveryCodeCount)
+	recoveryCodesHashed := make([]string, mfaRecoveryCodeCount)
+	for i := range recoveryCodes {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		hashed, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash recovery code: %w", err)
+		}
+
+		recoveryCodes[i] = code
+		recoveryCodesHashed[i] = string(hashed)
+	}
+
+	if _, err := s.mfaRepo.Create(user.ID, secretEnc, recoveryCodesHashed); err != nil {
+		return nil, fmt.Errorf("failed to store mfa enrollment: %w", err)
+	}
+
+	qrPNG, err := renderQRCodePNG(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render qr code: %w", err)
+	}
+
+	return &models.MFAEnrollResponse{
+		OTPAuthURI:    key.URL(),
+		QRCodePNG:     base64.StdEncoding.EncodeToString(qrPNG),
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+// ConfirmMFA validates the first TOTP code from the authenticator app and,
+// if correct, activates the pending enrollment.
+func (s *AuthService) ConfirmMFA(userID uuid.UUID, code string) error {
+	mfa, err := s.mfaRepo.GetByUserID(userID)
+	if err != nil {
+		return errors.New("mfa not enrolled")
+	}
+
+	valid, err := s.validateTOTPCode(mfa, code)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return errors.New("invalid code")
+	}
+
+	return s.mfaRepo.Confirm(userID)
+}
+
+// DisableMFA removes a user's MFA enrollment entirely, after checking a
+// current TOTP code to prove the caller still holds the second factor.
+func (s *AuthService) DisableMFA(userID uuid.UUID, code string) error {
+	mfa, err := s.mfaRepo.GetByUserID(userID)
+	if err != nil {
+		return errors.New("mfa not enrolled")
+	}
+
+	valid, err := s.validateTOTPCode(mfa, code)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return errors.New("invalid code")
+	}
+
+	return s.mfaRepo.Disable(userID)
+}
+
+// VerifyMFALogin completes a login by checking a TOTP code against the
+// pending MFA token issued by Login, then returns the user so the caller
+// can call GenerateTokens.
+func (s *AuthService) VerifyMFALogin(mfaToken, code string) (*models.User, error) {
+	userID, err := s.parseMFAPendingToken(mfaToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if allowed, err := s.rateLimiter.Allow("mfa-verify:user:"+userID.String(), mfaVerifyLimitPerUser, mfaVerifyLimitWindow); err != nil {
+		return nil, fmt.Errorf("failed to check rate limit: %w", err)
+	} else if !allowed {
+		return nil, errors.New("too many verification attempts, please try again later")
+	}
+
+	mfa, err := s.mfaRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, errors.New("mfa not enrolled")
+	}
+
+	valid, err := s.validateTOTPCode(mfa, code)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, errors.New("invalid code")
+	}
+
+	return s.userRepo.GetByID(userID)
+}
+
+// VerifyMFARecovery completes a login using a one-time recovery code
+// instead of a TOTP code, consuming it so it can't be reused.
+func (s *AuthService) VerifyMFARecovery(mfaToken, recoveryCode string) (*models.User, error) {
+	userID, err := s.parseMFAPendingToken(mfaToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if allowed, err := s.rateLimiter.Allow("mfa-verify:user:"+userID.String(), mfaVerifyLimitPerUser, mfaVerifyLimitWindow); err != nil {
+		return nil, fmt.Errorf("failed to check rate limit: %w", err)
+	} else if !allowed {
+		return nil, errors.New("too many verification attempts, please try again later")
+	}
+
+	mfa, err := s.mfaRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, errors.New("mfa not enrolled")
+	}
+
+	remaining := make([]string, 0, len(mfa.RecoveryCodesHashed))
+	matched := false
+	for _, hashed := range mfa.RecoveryCodesHashed {
+		if !matched && bcrypt.CompareHashAndPassword([]byte(hashed), []byte(recoveryCode)) == nil {
+			matched = true
+			continue
+		}
+		remaining = append(remaining, hashed)
+	}
+
+	if !matched {
+		return nil, errors.New("invalid recovery code")
+	}
+
+	if err := s.mfaRepo.ReplaceRecoveryCodes(userID, remaining); err != nil {
+		return nil, fmt.Errorf("failed to consume recovery code: %w", err)
+	}
+
+	return s.userRepo.GetByID(userID)
+}
+
+func (s *AuthService) validateTOTPCode(mfa *models.UserMFA, code string) (bool, error) {
+	secret, err := cryptoutil.Decrypt(s.mfaEncryptionKey, mfa.SecretEnc)
+	if err != nil {
+		return false, fmt.Errorf("failed to decrypt totp secret: %w", err)
+	}
+
+	valid, err := totp.ValidateCustom(code, secret, time.Now().UTC(), totp.ValidateOpts{
+		Period:    uint(totpPeriod.Seconds()),
+		Skew:      totpSkew,
+		Digits:    otp.DigitsSix,
+		Algorithm: otp.AlgorithmSHA1,
+	})
+	if err != nil || !valid {
+		return false, err
+	}
+
+	claimed, err := s.totpReplayGuard.Claim(mfa.UserID.String(), code, totpReplayWindow)
+	if err != nil {
+		return false, fmt.Errorf("failed to check totp replay: %w", err)
+	}
+	if !claimed {
+		return false, errors.New("code has already been used")
+	}
+
+	return true, nil
+}
+
+// GenerateMFAPendingToken issues a short-lived token identifying a user who
+// has passed password auth but still needs to complete MFA.
+func (s *AuthService) GenerateMFAPendingToken(user *models.User) (string, error) {
+	now := time.Now()
+	token := jwt.NewWithClaims(s.jwtKeys.SigningMethod(), jwt.MapClaims{
+		"sub":     user.ID,
+		"user_id": user.ID,
+		"type":    "mfa_pending",
+		"iat":     now.Unix(),
+		"exp":     now.Add(mfaPendingTokenExpiry).Unix(),
+	})
+	token.Header["kid"] = s.jwtKeys.CurrentKID()
+
+	return token.SignedString(s.jwtKeys.SigningKey())
+}
+
+func (s *AuthService) parseMFAPendingToken(tokenString string) (uuid.UUID, error) {
+	token, err := jwt.Parse(tokenString, s.jwtKeys.Keyfunc)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid mfa token: %w", err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return uuid.Nil, errors.New("invalid mfa token claims")
+	}
+
+	if tokenType, ok := claims["type"].(string); !ok || tokenType != "mfa_pending" {
+		return uuid.Nil, errors.New("invalid mfa token type")
+	}
+
+	userIDString, ok := claims["user_id"].(string)
+	if !ok {
+		return uuid.Nil, errors.New("invalid user ID in mfa token")
+	}
+
+	return uuid.Parse(userIDString)
+}
+
+func generateRecoveryCode() (string, error) {
+	bytes := make([]byte, 5)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+func renderQRCodePNG(key *otp.Key) ([]byte, error) {
+	img, err := key.Image(256, 256)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
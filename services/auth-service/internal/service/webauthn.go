@@ -0,0 +1,214 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	libwebauthn "github.com/go-webauthn/webauthn/webauthn"
+	"github.com/google/uuid"
+
+	"auth-service/internal/models"
+	webauthnutil "auth-service/internal/webauthn"
+)
+
+// BeginRegistration starts a passkey registration ceremony for an
+// authenticated user, returning the options the browser's
+// navigator.credentials.create() call needs and a challenge ID the client
+// must echo back to FinishRegistration.
+func (s *AuthService) BeginRegistration(user *models.User) (*models.WebAuthnBeginResponse, error) {
+	existing, err := s.credentialRepo.GetByUserID(user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list existing credentials: %w", err)
+	}
+
+	credUser := &webauthnutil.CredentialUser{User: user, Credentials: toLibraryCredentials(existing)}
+
+	options, session, err := s.webAuthn.BeginRegistration(credUser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin webauthn registration: %w", err)
+	}
+
+	challengeID, err := s.saveWebAuthnChallenge(session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.WebAuthnBeginResponse{ChallengeID: challengeID, Options: options}, nil
+}
+
+// FinishRegistration completes a passkey registration, validating r's body
+// against the session started by BeginRegistration and persisting the new
+// credential.
+func (s *AuthService) FinishRegistration(user *models.User, challengeID string, r *http.Request) (*models.WebAuthnCredential, error) {
+	session, err := s.webauthnChallenges.Get(challengeID)
+	if err != nil {
+		return nil, errors.New("registration ceremony expired or not found")
+	}
+	defer s.webauthnChallenges.Delete(challengeID)
+
+	credUser := &webauthnutil.CredentialUser{User: user}
+
+	cred, err := s.webAuthn.FinishRegistration(credUser, *session, r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify registration: %w", err)
+	}
+
+	stored, err := s.credentialRepo.Create(webauthnutil.FromLibraryCredential(user.ID, cred))
+	if err != nil {
+		return nil, err
+	}
+
+	return stored, nil
+}
+
+// BeginLogin starts a passkey login ceremony. If req.Email is blank, it
+// starts a discoverable-credential (resident key) flow where the
+// authenticator itself supplies the user handle in FinishLogin.
+func (s *AuthService) BeginLogin(req *models.WebAuthnLoginBeginRequest) (*models.WebAuthnBeginResponse, error) {
+	var (
+		options *protocol.CredentialAssertion
+		session *libwebauthn.SessionData
+		err     error
+	)
+
+	if req.Email == "" {
+		options, session, err = s.webAuthn.BeginDiscoverableLogin()
+	} else {
+		user, lookupErr := s.userRepo.GetByEmail(req.Email)
+		if lookupErr != nil {
+			return nil, errors.New("no passkeys registered for this account")
+		}
+
+		creds, credErr := s.credentialRepo.GetByUserID(user.ID)
+		if credErr != nil {
+			return nil, fmt.Errorf("failed to list credentials: %w", credErr)
+		}
+		if len(creds) == 0 {
+			return nil, errors.New("no passkeys registered for this account")
+		}
+
+		options, session, err = s.webAuthn.BeginLogin(&webauthnutil.CredentialUser{User: user, Credentials: toLibraryCredentials(creds)})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin webauthn login: %w", err)
+	}
+
+	challengeID, err := s.saveWebAuthnChallenge(session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.WebAuthnBeginResponse{ChallengeID: challengeID, Options: options}, nil
+}
+
+// FinishLogin completes a passkey login, verifying r's body against the
+// session started by BeginLogin and returning the authenticated user.
+func (s *AuthService) FinishLogin(challengeID string, r *http.Request) (*models.User, error) {
+	session, err := s.webauthnChallenges.Get(challengeID)
+	if err != nil {
+		return nil, errors.New("login ceremony expired or not found")
+	}
+	defer s.webauthnChallenges.Delete(challengeID)
+
+	var (
+		cred *libwebauthn.Credential
+		user *models.User
+	)
+
+	if len(session.UserID) == 0 {
+		cred, user, err = s.finishDiscoverableLogin(*session, r)
+	} else {
+		userID, parseErr := uuid.ParseBytes(session.UserID)
+		if parseErr != nil {
+			return nil, errors.New("invalid webauthn session")
+		}
+
+		user, err = s.userRepo.GetByID(userID)
+		if err == nil {
+			var creds []*models.WebAuthnCredential
+			creds, err = s.credentialRepo.GetByUserID(userID)
+			if err == nil {
+				cred, err = s.webAuthn.FinishLogin(&webauthnutil.CredentialUser{User: user, Credentials: toLibraryCredentials(creds)}, *session, r)
+			}
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify login: %w", err)
+	}
+
+	s.updateCredentialUsage(cred)
+
+	return user, nil
+}
+
+func (s *AuthService) finishDiscoverableLogin(session libwebauthn.SessionData, r *http.Request) (*libwebauthn.Credential, *models.User, error) {
+	var user *models.User
+
+	cred, err := s.webAuthn.FinishDiscoverableLogin(func(rawID, userHandle []byte) (libwebauthn.User, error) {
+		userID, err := uuid.ParseBytes(userHandle)
+		if err != nil {
+			return nil, errors.New("invalid user handle")
+		}
+
+		u, err := s.userRepo.GetByID(userID)
+		if err != nil {
+			return nil, errors.New("user not found")
+		}
+
+		creds, err := s.credentialRepo.GetByUserID(userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list credentials: %w", err)
+		}
+
+		user = u
+		return &webauthnutil.CredentialUser{User: u, Credentials: toLibraryCredentials(creds)}, nil
+	}, session, r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cred, user, nil
+}
+
+// updateCredentialUsage persists the post-assertion signature counter,
+// flagging the credential as compromised instead of erroring the login if
+// the counter regressed -- a sign the authenticator may have been cloned.
+func (s *AuthService) updateCredentialUsage(cred *libwebauthn.Credential) {
+	stored, err := s.credentialRepo.GetByCredentialID(cred.ID)
+	if err != nil {
+		return
+	}
+
+	if int64(cred.Authenticator.SignCount) > 0 && int64(cred.Authenticator.SignCount) <= stored.SignCount {
+		_ = s.credentialRepo.MarkCompromised(stored.ID)
+		return
+	}
+
+	_ = s.credentialRepo.UpdateSignCount(stored.ID, int64(cred.Authenticator.SignCount))
+}
+
+func (s *AuthService) saveWebAuthnChallenge(session *libwebauthn.SessionData) (string, error) {
+	idBytes := make([]byte, 16)
+	if _, err := rand.Read(idBytes); err != nil {
+		return "", fmt.Errorf("failed to generate challenge id: %w", err)
+	}
+	challengeID := hex.EncodeToString(idBytes)
+
+	if err := s.webauthnChallenges.Save(challengeID, session); err != nil {
+		return "", fmt.Errorf("failed to save webauthn challenge: %w", err)
+	}
+
+	return challengeID, nil
+}
+
+func toLibraryCredentials(creds []*models.WebAuthnCredential) []libwebauthn.Credential {
+	out := make([]libwebauthn.Credential, len(creds))
+	for i, c := range creds {
+		out[i] = webauthnutil.ToLibraryCredential(c)
+	}
+	return out
+}
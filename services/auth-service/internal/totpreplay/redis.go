@@ -0,0 +1,55 @@
+package totpreplay
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const keyPrefix = "auth:totp:used:"
+
+// RedisGuard backs Guard with Redis (SETNX semantics) so a code claimed on
+// one replica is rejected on every other one.
+type RedisGuard struct {
+	client *redis.Client
+}
+
+// NewRedisGuard connects to Redis using redisURL (a redis:// or rediss://
+// connection string) and the given connection pool size.
+func NewRedisGuard(redisURL string, poolSize int, useTLS bool) (*RedisGuard, error) {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse redis url: %w", err)
+	}
+
+	opts.PoolSize = poolSize
+	if useTLS && opts.TLSConfig == nil {
+		opts.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	client := redis.NewClient(opts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+
+	return &RedisGuard{client: client}, nil
+}
+
+func (g *RedisGuard) Claim(userID, code string, ttl time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	key := keyPrefix + userID + ":" + code
+	ok, err := g.client.SetNX(ctx, key, "1", ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim totp code: %w", err)
+	}
+
+	return ok, nil
+}
@@ -0,0 +1,44 @@
+// Package totpreplay stops a captured TOTP code from being replayed while
+// it's still within its validity window (one period plus the configured
+// clock-drift skew on either side).
+package totpreplay
+
+import (
+	"sync"
+	"time"
+)
+
+// Guard claims a (userID, code) pair for ttl, reporting false if it was
+// already claimed -- i.e. the code has already been used once this window.
+type Guard interface {
+	Claim(userID, code string, ttl time.Duration) (bool, error)
+}
+
+// InMemoryGuard is a process-local Guard. It doesn't share state across
+// replicas, so it's intended for local development and as the default when
+// no shared backend is configured.
+type InMemoryGuard struct {
+	mu      sync.Mutex
+	claimed map[string]time.Time // key -> expiry
+}
+
+func NewInMemoryGuard() *InMemoryGuard {
+	return &InMemoryGuard{
+		claimed: make(map[string]time.Time),
+	}
+}
+
+func (g *InMemoryGuard) Claim(userID, code string, ttl time.Duration) (bool, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	key := userID + ":" + code
+	now := time.Now()
+
+	if expiresAt, ok := g.claimed[key]; ok && now.Before(expiresAt) {
+		return false, nil
+	}
+
+	g.claimed[key] = now.Add(ttl)
+	return true, nil
+}
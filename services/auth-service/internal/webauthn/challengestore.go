@@ -0,0 +1,20 @@
+package webauthn
+
+import (
+	"time"
+
+	libwebauthn "github.com/go-webauthn/webauthn/webauthn"
+)
+
+// challengeTTL bounds how long a caller has to complete a registration or
+// login ceremony after starting it.
+const challengeTTL = 5 * time.Minute
+
+// ChallengeStore persists in-flight ceremony state (the library's
+// SessionData) between a begin call and its matching finish call, keyed by
+// a server-generated challenge ID handed back to the client.
+type ChallengeStore interface {
+	Save(challengeID string, session *libwebauthn.SessionData) error
+	Get(challengeID string) (*libwebauthn.SessionData, error)
+	Delete(challengeID string) error
+}
@@ -0,0 +1,58 @@
+package webauthn
+
+import (
+	"github.com/go-webauthn/webauthn/protocol"
+	libwebauthn "github.com/go-webauthn/webauthn/webauthn"
+	"github.com/google/uuid"
+
+	"auth-service/internal/models"
+)
+
+// ToLibraryCredential converts a stored credential row into the shape the
+// webauthn library expects when building ceremony options or verifying an
+// assertion.
+func ToLibraryCredential(c *models.WebAuthnCredential) libwebauthn.Credential {
+	transports := make([]protocol.AuthenticatorTransport, len(c.Transports))
+	for i, t := range c.Transports {
+		transports[i] = protocol.AuthenticatorTransport(t)
+	}
+
+	return libwebauthn.Credential{
+		ID:              c.CredentialID,
+		PublicKey:       c.PublicKey,
+		AttestationType: c.AttestationType,
+		Transport:       transports,
+		Flags: libwebauthn.CredentialFlags{
+			UserPresent:    true,
+			UserVerified:   c.UserVerified,
+			BackupEligible: c.BackupEligible,
+			BackupState:    c.BackupState,
+		},
+		Authenticator: libwebauthn.Authenticator{
+			AAGUID:    c.AAGUID,
+			SignCount: uint32(c.SignCount),
+		},
+	}
+}
+
+// FromLibraryCredential converts a freshly created library credential into
+// the row shape CredentialRepository persists.
+func FromLibraryCredential(userID uuid.UUID, cred *libwebauthn.Credential) *models.WebAuthnCredential {
+	transports := make([]string, len(cred.Transport))
+	for i, t := range cred.Transport {
+		transports[i] = string(t)
+	}
+
+	return &models.WebAuthnCredential{
+		UserID:          userID,
+		CredentialID:    cred.ID,
+		PublicKey:       cred.PublicKey,
+		AttestationType: cred.AttestationType,
+		AAGUID:          cred.Authenticator.AAGUID,
+		SignCount:       int64(cred.Authenticator.SignCount),
+		Transports:      transports,
+		UserVerified:    cred.Flags.UserVerified,
+		BackupEligible:  cred.Flags.BackupEligible,
+		BackupState:     cred.Flags.BackupState,
+	}
+}
@@ -0,0 +1,59 @@
+package webauthn
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	libwebauthn "github.com/go-webauthn/webauthn/webauthn"
+)
+
+type memoryEntry struct {
+	session   *libwebauthn.SessionData
+	expiresAt time.Time
+}
+
+// InMemoryChallengeStore is a process-local ChallengeStore. It doesn't
+// share state across replicas, so a load balancer that splits a ceremony's
+// begin and finish requests across instances needs the Redis-backed store
+// instead.
+type InMemoryChallengeStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+}
+
+func NewInMemoryChallengeStore() *InMemoryChallengeStore {
+	return &InMemoryChallengeStore{
+		entries: make(map[string]memoryEntry),
+	}
+}
+
+func (s *InMemoryChallengeStore) Save(challengeID string, session *libwebauthn.SessionData) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[challengeID] = memoryEntry{session: session, expiresAt: time.Now().Add(challengeTTL)}
+	return nil
+}
+
+func (s *InMemoryChallengeStore) Get(challengeID string) (*libwebauthn.SessionData, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[challengeID]
+	if !ok {
+		return nil, errors.New("challenge not found or expired")
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(s.entries, challengeID)
+		return nil, errors.New("challenge not found or expired")
+	}
+
+	return entry.session, nil
+}
+
+func (s *InMemoryChallengeStore) Delete(challengeID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, challengeID)
+	return nil
+}
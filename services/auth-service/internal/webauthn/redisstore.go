@@ -0,0 +1,84 @@
+package webauthn
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	libwebauthn "github.com/go-webauthn/webauthn/webauthn"
+	"github.com/redis/go-redis/v9"
+)
+
+const challengeKeyPrefix = "auth:webauthn:challenge:"
+
+// RedisChallengeStore backs ChallengeStore with Redis so a begin/finish
+// pair can land on different replicas behind a load balancer.
+type RedisChallengeStore struct {
+	client *redis.Client
+}
+
+// NewRedisChallengeStore connects to Redis using redisURL (a redis:// or
+// rediss:// connection string) and the given connection pool size.
+func NewRedisChallengeStore(redisURL string, poolSize int, useTLS bool) (*RedisChallengeStore, error) {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse redis url: %w", err)
+	}
+
+	opts.PoolSize = poolSize
+	if useTLS && opts.TLSConfig == nil {
+		opts.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	client := redis.NewClient(opts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+
+	return &RedisChallengeStore{client: client}, nil
+}
+
+func (s *RedisChallengeStore) Save(challengeID string, session *libwebauthn.SessionData) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webauthn session: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return s.client.Set(ctx, challengeKeyPrefix+challengeID, data, challengeTTL).Err()
+}
+
+func (s *RedisChallengeStore) Get(challengeID string) (*libwebauthn.SessionData, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	data, err := s.client.Get(ctx, challengeKeyPrefix+challengeID).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, errors.New("challenge not found or expired")
+		}
+		return nil, fmt.Errorf("failed to fetch webauthn session: %w", err)
+	}
+
+	var session libwebauthn.SessionData
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal webauthn session: %w", err)
+	}
+
+	return &session, nil
+}
+
+func (s *RedisChallengeStore) Delete(challengeID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return s.client.Del(ctx, challengeKeyPrefix+challengeID).Err()
+}
@@ -0,0 +1,33 @@
+package webauthn
+
+import (
+	libwebauthn "github.com/go-webauthn/webauthn/webauthn"
+
+	"auth-service/internal/models"
+)
+
+// CredentialUser adapts a models.User and its stored passkeys to the
+// webauthn.User interface the library's ceremonies operate on.
+type CredentialUser struct {
+	User        *models.User
+	Credentials []libwebauthn.Credential
+}
+
+func (u *CredentialUser) WebAuthnID() []byte {
+	return []byte(u.User.ID.String())
+}
+
+func (u *CredentialUser) WebAuthnName() string {
+	return u.User.Email
+}
+
+func (u *CredentialUser) WebAuthnDisplayName() string {
+	if u.User.FirstName != nil && *u.User.FirstName != "" {
+		return *u.User.FirstName
+	}
+	return u.User.Email
+}
+
+func (u *CredentialUser) WebAuthnCredentials() []libwebauthn.Credential {
+	return u.Credentials
+}
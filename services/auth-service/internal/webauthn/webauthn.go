@@ -0,0 +1,27 @@
+// Package webauthn adapts this service's users and stored credentials to
+// github.com/go-webauthn/webauthn, and provides a short-lived store for the
+// ceremony state the library needs between a begin and finish call.
+package webauthn
+
+import (
+	"fmt"
+
+	libwebauthn "github.com/go-webauthn/webauthn/webauthn"
+
+	"auth-service/internal/config"
+)
+
+// New builds the library's WebAuthn instance from the configured relying
+// party ID, display name, and allowed origins.
+func New(cfg *config.Config) (*libwebauthn.WebAuthn, error) {
+	wa, err := libwebauthn.New(&libwebauthn.Config{
+		RPID:          cfg.WebAuthnRPID,
+		RPDisplayName: cfg.WebAuthnRPDisplayName,
+		RPOrigins:     cfg.WebAuthnRPOrigins,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize webauthn: %w", err)
+	}
+
+	return wa, nil
+}
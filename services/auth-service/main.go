@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"database/sql"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
@@ -15,14 +16,22 @@ import (
 	_ "github.com/lib/pq"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
-	"github.com/sirupsen/logrus"
 
+	"auth-service/internal/blacklist"
 	"auth-service/internal/config"
 	"auth-service/internal/database"
 	"auth-service/internal/handlers"
+	"auth-service/internal/jwtkeys"
+	"auth-service/internal/logging"
+	"auth-service/internal/mailer"
 	"auth-service/internal/middleware"
+	"auth-service/internal/oauth"
+	"auth-service/internal/ratelimit"
+	"auth-service/internal/rbac"
 	"auth-service/internal/repository"
 	"auth-service/internal/service"
+	"auth-service/internal/totpreplay"
+	"auth-service/internal/webauthn"
 )
 
 var (
@@ -81,31 +90,96 @@ func init() {
 func main() {
 	// Load environment variables
 	if err := godotenv.Load(); err != nil {
-		logrus.Info("No .env file found, using environment variables")
+		slog.Info("No .env file found, using environment variables")
 	}
 
 	// Initialize configuration
 	cfg := config.Load()
 
 	// Setup logging
-	setupLogging(cfg)
+	logging.Init(cfg)
 
 	// Initialize database
 	db, err := database.Initialize(cfg.DatabaseURL)
 	if err != nil {
-		logrus.WithError(err).Fatal("Failed to initialize database")
+		slog.Error("Failed to initialize database", "error", err)
+		os.Exit(1)
 	}
 	defer db.Close()
 
 	// Run database migrations
 	if err := database.Migrate(db); err != nil {
-		logrus.WithError(err).Fatal("Failed to run database migrations")
+		slog.Error("Failed to run database migrations", "error", err)
+		os.Exit(1)
 	}
 
 	// Initialize dependencies
 	userRepo := repository.NewUserRepository(db)
-	authService := service.NewAuthService(userRepo)
-	authHandler := handlers.NewAuthHandler(authService)
+	loginProvider := service.NewPasswordLoginProvider(userRepo)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
+	passwordResetRepo := repository.NewPasswordResetRepository(db)
+	userLinkRepo := repository.NewUserLinkRepository(db)
+	mfaRepo := repository.NewMFARepository(db)
+	credentialRepo := repository.NewCredentialRepository(db)
+	sessionRepo := repository.NewSessionRepository(db)
+	accountLockoutRepo := repository.NewAccountLockoutRepository(db)
+	tokenBlacklist := initTokenBlacklist(cfg)
+	mailSender := initMailer(cfg)
+	rateLimiter := initRateLimiter(cfg)
+
+	oauthRegistry, err := oauth.NewRegistry(cfg.OIDCProviders)
+	if err != nil {
+		slog.Error("Failed to initialize OAuth providers", "error", err)
+		os.Exit(1)
+	}
+
+	jwtKeys, err := jwtkeys.Load(cfg.JWTPrivateKeyPath, cfg.JWTAlg, cfg.JWTCurrentKID)
+	if err != nil {
+		slog.Error("Failed to load JWT signing keys", "error", err)
+		os.Exit(1)
+	}
+
+	webAuthn, err := webauthn.New(cfg)
+	if err != nil {
+		slog.Error("Failed to initialize webauthn", "error", err)
+		os.Exit(1)
+	}
+	webauthnChallenges := initWebAuthnChallengeStore(cfg)
+	totpReplayGuard := initTOTPReplayGuard(cfg)
+
+	authService := service.NewAuthService(
+		userRepo,
+		loginProvider,
+		refreshTokenRepo,
+		passwordResetRepo,
+		userLinkRepo,
+		mfaRepo,
+		credentialRepo,
+		sessionRepo,
+		accountLockoutRepo,
+		tokenBlacklist,
+		mailSender,
+		rateLimiter,
+		oauthRegistry,
+		jwtKeys,
+		tokenOperations,
+		webAuthn,
+		webauthnChallenges,
+		totpReplayGuard,
+		cfg.JWTIssuer,
+		time.Duration(cfg.PasswordResetTTL)*time.Minute,
+		cfg.AppBaseURL,
+		cfg.OAuthTokenEncryptionKey,
+		cfg.MFAEncryptionKey,
+		cfg.AccountLockoutThreshold,
+		time.Duration(cfg.AccountLockoutBaseWindow)*time.Minute,
+	)
+	authHandler := handlers.NewAuthHandler(authService, authAttempts)
+
+	roleRegistry := rbac.DefaultRegistry()
+	if len(cfg.RolePermissions) > 0 {
+		roleRegistry = rbac.NewRegistry(cfg.RolePermissions)
+	}
 
 	// Setup Gin router
 	if cfg.Environment == "production" || cfg.Environment == "poc" {
@@ -115,6 +189,7 @@ func main() {
 	router := gin.New()
 
 	// Add middleware
+	router.Use(middleware.RequestContext())
 	router.Use(middleware.Logger())
 	router.Use(middleware.Recovery())
 	router.Use(middleware.CORS())
@@ -128,6 +203,10 @@ func main() {
 	// Metrics endpoint
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
+	// Key discovery endpoints for other services to verify our tokens
+	router.GET("/.well-known/jwks.json", authHandler.JWKS)
+	router.GET("/.well-known/openid-configuration", authHandler.OpenIDConfiguration)
+
 	// API routes
 	v1 := router.Group("/api/v1")
 	{
@@ -140,27 +219,79 @@ func main() {
 			})
 		})
 		
-		v1.POST("/register", authHandler.Register)
-		v1.POST("/login", authHandler.Login)
+		v1.POST("/register", middleware.RateLimit(rateLimiter, authAttempts, "register", cfg.RegisterRateLimitAttempts, time.Duration(cfg.RegisterRateLimitWindow)*time.Minute, middleware.RateLimitByIP), authHandler.Register)
+		v1.POST("/login", middleware.RateLimit(rateLimiter, authAttempts, "login", cfg.LoginRateLimitAttempts, time.Duration(cfg.LoginRateLimitWindow)*time.Minute, middleware.RateLimitByIP), authHandler.Login)
 		v1.POST("/refresh", authHandler.RefreshToken)
 		v1.POST("/verify", authHandler.VerifyToken)
 		v1.POST("/logout", authHandler.Logout)
-		v1.POST("/forgot-password", authHandler.ForgotPassword)
+		v1.POST("/forgot-password", middleware.RateLimit(rateLimiter, authAttempts, "forgot_password", cfg.ForgotPasswordRateLimitAttempts, time.Duration(cfg.ForgotPasswordRateLimitWindow)*time.Minute, middleware.RateLimitByEmail), authHandler.ForgotPassword)
 		v1.POST("/reset-password", authHandler.ResetPassword)
+
+		v1.GET("/auth/oauth/:provider/start", authHandler.OAuthStart)
+		v1.GET("/auth/oauth/:provider/callback", authHandler.OAuthCallback)
+
+		// Aliases matching the provider-abstraction naming used elsewhere
+		// ("login" to start, "callback" to finish).
+		v1.GET("/oauth/:provider/login", authHandler.OAuthStart)
+		v1.GET("/oauth/:provider/callback", authHandler.OAuthCallback)
+
+		v1.POST("/auth/mfa/enroll", middleware.Authenticate(authService), authHandler.MFAEnroll)
+		v1.POST("/auth/mfa/confirm", middleware.Authenticate(authService), authHandler.MFAConfirm)
+		v1.POST("/auth/mfa/verify", authHandler.MFAVerify)
+		v1.POST("/auth/mfa/recovery", authHandler.MFARecovery)
+		v1.POST("/auth/mfa/disable", middleware.Authenticate(authService), authHandler.MFADisable)
+
+		// Aliases matching the endpoint naming used by some clients
+		// ("mfa/totp/..." for TOTP enrollment steps, "login/mfa" for the
+		// challenge-token step of Login).
+		v1.POST("/mfa/totp/enroll", middleware.Authenticate(authService), authHandler.MFAEnroll)
+		v1.POST("/mfa/totp/verify", middleware.Authenticate(authService), authHandler.MFAConfirm)
+		v1.POST("/mfa/totp/disable", middleware.Authenticate(authService), authHandler.MFADisable)
+		v1.POST("/login/mfa", authHandler.MFAVerify)
+
+		v1.POST("/webauthn/register/begin", middleware.Authenticate(authService), authHandler.WebAuthnRegisterBegin)
+		v1.POST("/webauthn/register/finish", middleware.Authenticate(authService), authHandler.WebAuthnRegisterFinish)
+		v1.POST("/webauthn/login/begin", authHandler.WebAuthnLoginBegin)
+		v1.POST("/webauthn/login/finish", authHandler.WebAuthnLoginFinish)
+
+		v1.POST("/sessions", middleware.Authenticate(authService), authHandler.ListSessions)
+		v1.DELETE("/sessions", middleware.Authenticate(authService), authHandler.RevokeAllSessions)
+		v1.DELETE("/sessions/:sid", middleware.Authenticate(authService), authHandler.RevokeSession)
+
+		admin := v1.Group("/admin")
+		admin.Use(middleware.Authenticate(authService))
+		{
+			admin.GET("/users", middleware.RequirePermission(roleRegistry, rbac.PermissionUsersRead), authHandler.ListUsers)
+			admin.PATCH("/users/:id/role", middleware.RequirePermission(roleRegistry, rbac.PermissionUsersManage), authHandler.UpdateUserRole)
+			admin.POST("/users/:id/activate", middleware.RequirePermission(roleRegistry, rbac.PermissionUsersManage), authHandler.ActivateUser)
+			admin.POST("/users/:id/deactivate", middleware.RequirePermission(roleRegistry, rbac.PermissionUsersManage), authHandler.DeactivateUser)
+			admin.POST("/users/:id/force-logout", middleware.RequirePermission(roleRegistry, rbac.PermissionUsersManage), authHandler.ForceLogout)
+		}
 	}
 
-	// Backwards compatibility routes (no /api/v1 prefix)
-	router.POST("/register", authHandler.Register)
-	router.POST("/login", authHandler.Login)
+	// Backwards compatibility routes (no /api/v1 prefix). These call the
+	// same handlers as the /api/v1 routes above, so they carry the same
+	// rate limiting — otherwise the brute-force protection on /login et al.
+	// would be bypassable just by dropping the prefix.
+	router.POST("/register", middleware.RateLimit(rateLimiter, authAttempts, "register", cfg.RegisterRateLimitAttempts, time.Duration(cfg.RegisterRateLimitWindow)*time.Minute, middleware.RateLimitByIP), authHandler.Register)
+	router.POST("/login", middleware.RateLimit(rateLimiter, authAttempts, "login", cfg.LoginRateLimitAttempts, time.Duration(cfg.LoginRateLimitWindow)*time.Minute, middleware.RateLimitByIP), authHandler.Login)
 	router.POST("/refresh", authHandler.RefreshToken)
 	router.POST("/verify", authHandler.VerifyToken)
 	router.POST("/logout", authHandler.Logout)
-	router.POST("/forgot-password", authHandler.ForgotPassword)
+	router.POST("/forgot-password", middleware.RateLimit(rateLimiter, authAttempts, "forgot_password", cfg.ForgotPasswordRateLimitAttempts, time.Duration(cfg.ForgotPasswordRateLimitWindow)*time.Minute, middleware.RateLimitByEmail), authHandler.ForgotPassword)
 	router.POST("/reset-password", authHandler.ResetPassword)
 
 	// Start database metrics collection
 	go collectDatabaseMetrics(db)
 
+	// Periodically purge expired refresh tokens
+	go purgeExpiredRefreshTokens(authService)
+
+	// Periodically rotate the JWT signing key, if configured to
+	if cfg.JWTKeyRotationInterval > 0 {
+		go rotateJWTKeys(jwtKeys, time.Duration(cfg.JWTKeyRotationInterval)*time.Hour, authService.KeyRetentionWindow())
+	}
+
 	// Setup server
 	srv := &http.Server{
 		Addr:         ":" + strconv.Itoa(cfg.Port),
@@ -172,13 +303,11 @@ func main() {
 
 	// Start server in a goroutine
 	go func() {
-		logrus.WithFields(logrus.Fields{
-			"port":        cfg.Port,
-			"environment": cfg.Environment,
-		}).Info("Starting auth service")
+		slog.Info("Starting auth service", "port", cfg.Port, "environment", cfg.Environment)
 
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			logrus.WithError(err).Fatal("Failed to start server")
+			slog.Error("Failed to start server", "error", err)
+			os.Exit(1)
 		}
 	}()
 
@@ -187,35 +316,18 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	logrus.Info("Shutting down server...")
+	slog.Info("Shutting down server...")
 
 	// Give outstanding requests a deadline for completion
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	if err := srv.Shutdown(ctx); err != nil {
-		logrus.WithError(err).Fatal("Server forced to shutdown")
+		slog.Error("Server forced to shutdown", "error", err)
+		os.Exit(1)
 	}
 
-	logrus.Info("Server exited")
-}
-
-func setupLogging(cfg *config.Config) {
-	level, err := logrus.ParseLevel(cfg.LogLevel)
-	if err != nil {
-		level = logrus.InfoLevel
-	}
-
-	logrus.SetLevel(level)
-	logrus.SetFormatter(&logrus.JSONFormatter{
-		TimestampFormat: time.RFC3339,
-	})
-
-	if cfg.Environment == "development" {
-		logrus.SetFormatter(&logrus.TextFormatter{
-			FullTimestamp: true,
-		})
-	}
+	slog.Info("Server exited")
 }
 
 func healthCheck(db *sql.DB) gin.HandlerFunc {
@@ -268,6 +380,110 @@ func livenessCheck() gin.HandlerFunc {
 	}
 }
 
+func initTokenBlacklist(cfg *config.Config) blacklist.TokenBlacklist {
+	if cfg.RedisURL == "" {
+		slog.Warn("REDIS_URL not set, using in-memory token blacklist (not safe across replicas)")
+		return blacklist.NewInMemoryBlacklist()
+	}
+
+	redisBlacklist, err := blacklist.NewRedisBlacklist(cfg.RedisURL, cfg.RedisPoolSize, cfg.RedisTLS)
+	if err != nil {
+		slog.Error("Failed to initialize redis token blacklist", "error", err)
+		os.Exit(1)
+	}
+
+	return redisBlacklist
+}
+
+func initRateLimiter(cfg *config.Config) ratelimit.Limiter {
+	if cfg.RedisURL == "" {
+		slog.Warn("REDIS_URL not set, using in-memory rate limiter (not safe across replicas)")
+		return ratelimit.NewInMemoryLimiter()
+	}
+
+	redisLimiter, err := ratelimit.NewRedisLimiter(cfg.RedisURL, cfg.RedisPoolSize, cfg.RedisTLS)
+	if err != nil {
+		slog.Error("Failed to initialize redis rate limiter", "error", err)
+		os.Exit(1)
+	}
+
+	return redisLimiter
+}
+
+func initWebAuthnChallengeStore(cfg *config.Config) webauthn.ChallengeStore {
+	if cfg.RedisURL == "" {
+		slog.Warn("REDIS_URL not set, using in-memory webauthn challenge store (not safe across replicas)")
+		return webauthn.NewInMemoryChallengeStore()
+	}
+
+	redisStore, err := webauthn.NewRedisChallengeStore(cfg.RedisURL, cfg.RedisPoolSize, cfg.RedisTLS)
+	if err != nil {
+		slog.Error("Failed to initialize redis webauthn challenge store", "error", err)
+		os.Exit(1)
+	}
+
+	return redisStore
+}
+
+func initTOTPReplayGuard(cfg *config.Config) totpreplay.Guard {
+	if cfg.RedisURL == "" {
+		slog.Warn("REDIS_URL not set, using in-memory totp replay guard (not safe across replicas)")
+		return totpreplay.NewInMemoryGuard()
+	}
+
+	redisGuard, err := totpreplay.NewRedisGuard(cfg.RedisURL, cfg.RedisPoolSize, cfg.RedisTLS)
+	if err != nil {
+		slog.Error("Failed to initialize redis totp replay guard", "error", err)
+		os.Exit(1)
+	}
+
+	return redisGuard
+}
+
+func initMailer(cfg *config.Config) mailer.Mailer {
+	if cfg.SMTPHost == "" {
+		slog.Warn("SMTP_HOST not set, using log mailer (emails will not actually be sent)")
+		return mailer.NewLogMailer()
+	}
+
+	return mailer.NewSMTPMailer(mailer.SMTPConfig{
+		Host: cfg.SMTPHost,
+		Port: cfg.SMTPPort,
+		User: cfg.SMTPUser,
+		Pass: cfg.SMTPPass,
+		From: cfg.SMTPFrom,
+	})
+}
+
+func purgeExpiredRefreshTokens(authService *service.AuthService) {
+	ticker := time.NewTicker(1 * time.Hour)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		authService.PurgeExpiredRefreshTokens()
+	}
+}
+
+// rotateJWTKeys periodically rolls the JWT signing key, keeping each key in
+// the JWKS for interval (its time as the current key) plus retainFor (the
+// longer of the access and refresh token TTLs) after it was created, so
+// every in-flight token still verifies but the key set doesn't grow forever.
+func rotateJWTKeys(jwtKeys *jwtkeys.Manager, interval, retainFor time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		kid, err := jwtKeys.Rotate()
+		if err != nil {
+			slog.Error("Failed to rotate jwt signing key", "error", err)
+			continue
+		}
+		slog.Info("Rotated jwt signing key", "kid", kid)
+
+		jwtKeys.PruneRetired(interval + retainFor)
+	}
+}
+
 func collectDatabaseMetrics(db *sql.DB) {
 	ticker := time.NewTicker(30 * time.Second)
 	defer ticker.Stop()